@@ -0,0 +1,167 @@
+package eval
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Methods declared by interpreted code only exist within the interpreter,
+// so values of a Named type cannot implement host interfaces directly.
+// Instead, the host may register an adapter for an interface: a struct
+// type with a func field <Method>Func for each method of the interface,
+// where the struct or its pointer implements the interface by calling these
+// fields. The adapter must also have a field Adapted interface{}, which
+// holds the adapted value for the interpreter and should not be used by the
+// host. For example, an adapter for sort.Interface is
+//
+//  type SortAdapter struct {
+//  	LenFunc func() int
+//  	LessFunc func(i, j int) bool
+//  	SwapFunc func(i, j int)
+//  	Adapted interface{}
+//  }
+//
+//  func (a *SortAdapter) Len() int           { return a.LenFunc() }
+//  func (a *SortAdapter) Less(i, j int) bool { return a.LessFunc(i, j) }
+//  func (a *SortAdapter) Swap(i, j int)      { a.SwapFunc(i, j) }
+//
+// When a value of a Named type is converted to a registered interface, it
+// is wrapped in a new adapter whose fields call the value's methods. The
+// interface holds the adapter if it implements the interface, and a pointer
+// to the adapter otherwise. Adapters for error and fmt.Stringer are
+// registered by default, and are also used when a value of a Named type
+// implementing them is passed to a host interface{}, so that the fmt
+// package calls its methods.
+var adapters = struct {
+	sync.RWMutex
+	m map[reflect.Type]reflect.Type
+
+	// The registered adapter types
+	types map[reflect.Type]bool
+}{m: map[reflect.Type]reflect.Type{}, types: map[reflect.Type]bool{}}
+
+type errorAdapter struct {
+	ErrorFunc func() string
+	Adapted interface{}
+}
+
+func (a *errorAdapter) Error() string { return a.ErrorFunc() }
+
+type fmtStringerAdapter struct {
+	StringFunc func() string
+	Adapted interface{}
+}
+
+func (a *fmtStringerAdapter) String() string { return a.StringFunc() }
+
+var stringerType = reflect.TypeOf(new(fmt.Stringer)).Elem()
+
+// Interfaces whose adapters are passed to host interface{}, in order of
+// preference.
+var emptyInterfaceAdapted = []reflect.Type{errorType, stringerType}
+
+func init() {
+	RegisterAdapter(errorType, reflect.TypeOf(errorAdapter{}))
+	RegisterAdapter(stringerType, reflect.TypeOf(fmtStringerAdapter{}))
+}
+
+// Register adapter as the adapter of the host interface type iface,
+// replacing any previously registered adapter.
+func RegisterAdapter(iface, adapter reflect.Type) error {
+	if iface.Kind() != reflect.Interface || iface.NumMethod() == 0 {
+		return fmt.Errorf("cannot register adapter for %v: not a non-empty interface type", iface)
+	} else if adapter.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot register adapter %v: not a struct type", adapter)
+	} else if !adapter.Implements(iface) && !reflect.PtrTo(adapter).Implements(iface) {
+		return fmt.Errorf("cannot register adapter %v: does not implement %v", adapter, iface)
+	}
+	for i := 0; i < iface.NumMethod(); i += 1 {
+		m := iface.Method(i)
+		field, ok := adapter.FieldByName(m.Name + "Func")
+		if !ok || field.PkgPath != "" || field.Type != m.Type {
+			return fmt.Errorf("cannot register adapter %v: missing field %sFunc %v", adapter, m.Name, m.Type)
+		}
+	}
+	if field, ok := adapter.FieldByName("Adapted"); !ok || field.PkgPath != "" || field.Type != emptyInterface {
+		return fmt.Errorf("cannot register adapter %v: missing field Adapted interface{}", adapter)
+	}
+	adapters.Lock()
+	defer adapters.Unlock()
+	adapters.m[iface] = adapter
+	adapters.types[adapter] = true
+	return nil
+}
+
+// Returns the adapter registered for iface, or nil if there is none.
+func adapterFor(iface reflect.Type) reflect.Type {
+	adapters.RLock()
+	defer adapters.RUnlock()
+	return adapters.m[iface]
+}
+
+func isAdapter(t reflect.Type) bool {
+	adapters.RLock()
+	defer adapters.RUnlock()
+	return adapters.types[t]
+}
+
+// Returns a new adapter of type adapter for the host interface iface, whose
+// methods call those of v, a value of the Named type t.
+func adapt(v reflect.Value, t *Named, iface, adapter reflect.Type) reflect.Value {
+	a := reflect.New(adapter)
+	for i := 0; i < iface.NumMethod(); i += 1 {
+		name := iface.Method(i).Name
+		a.Elem().FieldByName(name + "Func").Set(t.lookupMethod(name).bind(v))
+	}
+	a.Elem().FieldByName("Adapted").Set(boxNamed(v, t))
+	if adapter.Implements(iface) {
+		return a.Elem()
+	}
+	return a
+}
+
+// Returns the value of a Named type wrapped by the adapter a, or a pointer
+// to it, or a nil Named if a is not an adapter.
+func adaptedBy(a reflect.Value) (reflect.Value, *Named) {
+	if a.Kind() == reflect.Ptr && !a.IsNil() {
+		a = a.Elem()
+	}
+	if a.Kind() != reflect.Struct || !isAdapter(a.Type()) {
+		return reflect.Value{}, nil
+	}
+	box := a.FieldByName("Adapted").Elem()
+	if !box.IsValid() {
+		return reflect.Value{}, nil
+	} else if named := boxedNamed(box.Type()); named != nil {
+		return box.Field(0), named
+	}
+	return reflect.Value{}, nil
+}
+
+// Convert v, a value of static type t, to a value which may be assigned to
+// the interface type to. Values of Named types are boxed if to is an
+// interface held by the interpreter, or adapted if to is a host interface
+// with methods. Boxes passed on to the host are replaced by hostValue.
+func interfaceValue(v reflect.Value, t, to reflect.Type) reflect.Value {
+	named, ok := t.(*Named)
+	if !ok || v.Type() != named.Type {
+		return v
+	} else if _, virtual := to.(*Interface); virtual || to.NumMethod() == 0 {
+		return boxNamed(v, named)
+	} else if adapter := adapterFor(to); adapter != nil && missingMethod(to, named) == "" {
+		return adapt(v, named, to, adapter)
+	}
+	return v
+}
+
+// Returns v, a value of the Named type t passed to a host interface{}, in
+// the adapter of an interface it implements, or v if there is none.
+func emptyInterfaceAdapter(v reflect.Value, t *Named) reflect.Value {
+	for _, iface := range emptyInterfaceAdapted {
+		if adapter := adapterFor(iface); adapter != nil && missingMethod(iface, t) == "" {
+			return adapt(v, t, iface, adapter)
+		}
+	}
+	return v
+}
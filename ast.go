@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"go/ast"
 	"go/token"
@@ -111,6 +112,12 @@ type SelectorExpr struct {
 
 	// the method index
 	method int
+
+	// if not nil, a method declared by interpreted code
+	namedMethod *namedMethod
+
+	// if true, X is a type and this is a method expression
+	isMethodExpr bool
 }
 
 type IndexExpr struct {
@@ -125,6 +132,19 @@ type IndexExpr struct {
 	constValue
 }
 
+// An instantiation F[T1, T2] of a registered generic host func. Index
+// expressions F[T] of a generic func are also represented by an
+// IndexListExpr.
+type IndexListExpr struct {
+	*ast.IndexListExpr
+	X Expr
+	Indices []Expr
+	knownType
+
+	// the instantiation selected during checking
+	fun reflect.Value
+}
+
 type SliceExpr struct {
 	*ast.SliceExpr
 	X Expr
@@ -205,6 +225,7 @@ type ArrayType struct {
 
 type StructType struct {
 	*ast.StructType
+	Fields *FieldList
 	knownType
 }
 
@@ -217,6 +238,7 @@ type FuncType struct {
 
 type InterfaceType struct {
 	*ast.InterfaceType
+	Methods *FieldList
 	knownType
 }
 
@@ -272,6 +294,61 @@ type BlockStmt struct {
 	List []Stmt
 }
 
+type DeclStmt struct {
+	*ast.DeclStmt
+	Specs []Spec
+
+	// if not nil, this declares a method, and Specs is nil
+	Method *FuncDecl
+}
+
+// A func or method declaration. Methods are checked and evaluated as func
+// literals whose first parameter is the receiver.
+type FuncDecl struct {
+	*ast.FuncDecl
+	Lit *FuncLit
+
+	// nil for funcs and methods named _
+	method *namedMethod
+	recv *Named
+}
+
+// Annotated ast.Spec nodes, either *ValueSpec, *TypeSpec or *ImportSpec
+type Spec interface {
+	ast.Spec
+}
+
+type ValueSpec struct {
+	*ast.ValueSpec
+	Names []*Ident
+	Type Expr
+	Values []Expr
+
+	// Is this a const or var spec
+	tok token.Token
+
+	// Type of each declared name
+	types []reflect.Type
+
+	// Values of each declared const
+	consts []reflect.Value
+}
+
+type TypeSpec struct {
+	*ast.TypeSpec
+	Name *Ident
+	Type Expr
+}
+
+type ImportSpec struct {
+	*ast.ImportSpec
+	path string
+
+	// The name the package is bound to, which may be . or _
+	name string
+	pkg Env
+}
+
 type EmptyStmt struct {
 	*ast.EmptyStmt
 }
@@ -281,6 +358,16 @@ type ExprStmt struct {
 	X Expr
 }
 
+type DeferStmt struct {
+	*ast.DeferStmt
+	Call *CallExpr
+}
+
+type GoStmt struct {
+	*ast.GoStmt
+	Call *CallExpr
+}
+
 type IfStmt struct {
 	*ast.IfStmt
 	Init Stmt
@@ -304,11 +391,47 @@ type ForStmt struct {
 	label string
 }
 
+type RangeStmt struct {
+	*ast.RangeStmt
+	Key Expr
+	Value Expr
+	X Expr
+	Body *BlockStmt
+	label string
+
+	// type of X after default promotion of untyped constants
+	xT reflect.Type
+
+	// types of the iteration variables
+	keyT, valueT reflect.Type
+}
+
 type ReturnStmt struct {
 	*ast.ReturnStmt
 	Results []Expr
 }
 
+type SelectStmt struct {
+	*ast.SelectStmt
+	Body *BlockStmt
+	label string
+}
+
+type CommClause struct {
+	*ast.CommClause
+	Comm Stmt
+	Body []Stmt
+
+	// The receive operation of a receive case
+	recv *UnaryExpr
+}
+
+type SendStmt struct {
+	*ast.SendStmt
+	Chan Expr
+	Value Expr
+}
+
 type SwitchStmt struct {
 	*ast.SwitchStmt
 	Init Stmt
@@ -317,6 +440,7 @@ type SwitchStmt struct {
 
 	tagT reflect.Type
 	def Stmt // This is a *CaseClause, but we want it to be nil-able
+	label string
 }
 
 type TypeSwitchStmt struct {
@@ -327,6 +451,7 @@ type TypeSwitchStmt struct {
 
 	clauses map[reflect.Type] CaseClause
 	def Stmt // This is a *CaseClause, but we want it to be nil-able
+	label string
 }
 
 func (typeswitch *TypeSwitchStmt) Tag() Expr {
@@ -367,6 +492,7 @@ func (*Ellipsis) IsConst() bool       { return false }
 func (*FuncLit) IsConst() bool        { return false }
 func (*CompositeLit) IsConst() bool   { return false }
 func (*IndexExpr) IsConst() bool      { return false }
+func (*IndexListExpr) IsConst() bool  { return false }
 func (*SliceExpr) IsConst() bool      { return false }
 func (*TypeAssertExpr) IsConst() bool { return false }
 func (*StarExpr) IsConst() bool       { return false }
@@ -383,6 +509,7 @@ func (*Ellipsis) Const() reflect.Value       { return reflect.Value{} }
 func (*FuncLit) Const() reflect.Value        { return reflect.Value{} }
 func (*CompositeLit) Const() reflect.Value   { return reflect.Value{} }
 func (*IndexExpr) Const() reflect.Value      { return reflect.Value{} }
+func (*IndexListExpr) Const() reflect.Value  { return reflect.Value{} }
 func (*SliceExpr) Const() reflect.Value      { return reflect.Value{} }
 func (*TypeAssertExpr) Const() reflect.Value { return reflect.Value{} }
 func (*StarExpr) Const() reflect.Value       { return reflect.Value{} }
@@ -406,6 +533,7 @@ func (e *CompositeLit) setKnownType(t knownType)   { e.knownType = t }
 func (e *SelectorExpr) setKnownType(t knownType)   { e.knownType = t }
 func (e *Ident) setKnownType(t knownType)          { e.knownType = t }
 func (e *IndexExpr) setKnownType(t knownType)      { e.knownType = t }
+func (e *IndexListExpr) setKnownType(t knownType)  { e.knownType = t }
 func (e *ParenExpr) setKnownType(t knownType)      { e.knownType = t }
 func (e *SliceExpr) setKnownType(t knownType)      { e.knownType = t }
 func (e *TypeAssertExpr) setKnownType(t knownType) { e.knownType = t }
@@ -470,6 +598,8 @@ func (lit *CompositeLit) String() string {
 		return fmt.Sprintf("[]%v literal", t.Elem())
 	case reflect.Map:
 		return fmt.Sprintf("map[%v]%v literal", t.Key(), t.Elem())
+	case reflect.Array, reflect.Struct:
+		return fmt.Sprintf("%v literal", t)
 	default:
 		return "TODO composite lit"
 	}
@@ -490,6 +620,19 @@ func (index *IndexExpr) String() string {
 	return fmt.Sprintf("%v[%v]", index.X, index.Index)
 }
 
+func (index *IndexListExpr) String() string {
+	s := fmt.Sprint(index.X)
+	if len(index.Indices) == 0 {
+		return s
+	}
+	sep := "["
+	for _, i := range index.Indices {
+		s += fmt.Sprintf("%v%v", sep, i)
+		sep = ", "
+	}
+	return s + "]"
+}
+
 func (slice *SliceExpr) String() string {
 	// TODO update for :: with go 1.2 upgrade
 	var low, high string
@@ -567,9 +710,58 @@ func (arrayType *ArrayType) String() string {
 	}
 }
 
-func (structType *StructType) String() string { return "TODO  structType.StructType" }
-func (funcType *FuncType) String() string { return "TODO  funcType.FuncType" }
-func (interfaceType *InterfaceType) String() string { return "TODO  interfaceType.InterfaceType" }
+func (structType *StructType) String() string {
+	if structType.Fields == nil || len(structType.Fields.List) == 0 {
+		return "struct {}"
+	}
+	s := "struct {"
+	sep := " "
+	for _, field := range structType.Fields.List {
+		s += sep
+		for i, name := range field.Names {
+			if i != 0 {
+				s += ", "
+			}
+			s += name.Name
+		}
+		if field.Names != nil {
+			s += " "
+		}
+		s += fmt.Sprint(field.Type)
+		if field.Tag != nil {
+			s += " " + field.Tag.Value
+		}
+		sep = "; "
+	}
+	return s + " }"
+}
+func (funcType *FuncType) String() string {
+	s := "func" + sprintFieldTypes(funcType.Params, true)
+	if funcType.Results == nil || len(funcType.Results.List) == 0 {
+		return s
+	}
+	// A single unnamed result is not parenthesised
+	if r := funcType.Results.List; len(r) == 1 && len(r[0].Names) <= 1 {
+		return s + " " + sprintFieldTypes(funcType.Results, false)
+	}
+	return s + " " + sprintFieldTypes(funcType.Results, true)
+}
+func (interfaceType *InterfaceType) String() string {
+	if interfaceType.Methods == nil || len(interfaceType.Methods.List) == 0 {
+		return "interface {}"
+	}
+	s := "interface {"
+	sep := " "
+	for _, field := range interfaceType.Methods.List {
+		if funcT, ok := field.Type.(*FuncType); ok && field.Names != nil {
+			s += sep + field.Names[0].Name + strings.TrimPrefix(funcT.String(), "func")
+		} else {
+			s += fmt.Sprintf("%s%v", sep, field.Type)
+		}
+		sep = "; "
+	}
+	return s + " }"
+}
 
 func (mapType *MapType) String() string {
 	return fmt.Sprintf("map[%v]%v", mapType.Key, mapType.Value)
@@ -587,6 +779,25 @@ func (chanType *ChanType) String() string {
 	}
 }
 
+// Prints the types of a param or result list, repeating the type of
+// fields which declare multiple names.
+func sprintFieldTypes(list *FieldList, parens bool) string {
+	var s string
+	if list != nil {
+		sep := ""
+		for _, field := range list.List {
+			for i := 0; i == 0 || i < len(field.Names); i += 1 {
+				s += fmt.Sprintf("%s%v", sep, field.Type)
+				sep = ", "
+			}
+		}
+	}
+	if parens {
+		return "(" + s + ")"
+	}
+	return s
+}
+
 // Returns a printable interface{} which replaces constant expressions with their constants
 func simplifyBinaryChildExpr(parent *BinaryExpr, expr, other Expr) interface{} {
 	op := parent.Op()
@@ -1,10 +1,17 @@
 package eval
 
 import (
-	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
 	"reflect"
+	"unsafe"
 )
 
+// PrintOutput receives the output of the print and println builtins.
+var PrintOutput io.Writer = os.Stderr
+
 var (
 	intType reflect.Type = reflect.TypeOf(int(0))
 	i8 reflect.Type = reflect.TypeOf(int8(0))
@@ -27,6 +34,8 @@ var (
 	stringType reflect.Type = reflect.TypeOf(string(""))
 
 	emptyInterface reflect.Type = reflect.TypeOf(new(interface{})).Elem()
+	errorType reflect.Type = reflect.TypeOf(new(error)).Elem()
+	uintptrType reflect.Type = reflect.TypeOf(uintptr(0))
 
 	byteSlice reflect.Type = reflect.SliceOf(u8)
 	runeSlice reflect.Type = reflect.SliceOf(i32)
@@ -57,7 +66,10 @@ var builtinTypes = map[string] reflect.Type{
 	"rune": RuneType,
 	"string": stringType,
 
-	"error": reflect.TypeOf(errors.New("")),
+	"uintptr": uintptrType,
+
+	"error": errorType,
+	"any": emptyInterface,
 }
 
 var builtinFuncs = map[string] reflect.Value{
@@ -71,6 +83,13 @@ var builtinFuncs = map[string] reflect.Value{
 	"copy": reflect.ValueOf(builtinCopy),
 	"delete": reflect.ValueOf(builtinDelete),
 	"panic": reflect.ValueOf(builtinPanic),
+	"recover": reflect.ValueOf(builtinRecover),
+	"close": reflect.ValueOf(builtinClose),
+	"clear": reflect.ValueOf(builtinClear),
+	"print": reflect.ValueOf(builtinPrint),
+	"println": reflect.ValueOf(builtinPrintln),
+	"min": reflect.ValueOf(builtinMin),
+	"max": reflect.ValueOf(builtinMax),
 }
 
 func builtinComplex(re, im reflect.Value) reflect.Value {
@@ -129,3 +148,195 @@ func builtinDelete(m, k reflect.Value) reflect.Value {
 func builtinPanic(i reflect.Value) error {
 	return PanicUser(i)
 }
+
+// Stop the panic of the frame which deferred frame, returning the value
+// passed to panic, or the runtime error.
+func builtinRecover(frame *funcFrame) reflect.Value {
+	r := reflect.New(emptyInterface).Elem()
+	if frame == nil || frame.deferredBy == nil {
+		return r
+	} else if p := frame.deferredBy; p.panicking != nil {
+		if user, ok := p.panicking.(PanicUser); ok {
+			r.Set(reflect.Value(user))
+		} else {
+			r.Set(reflect.ValueOf(p.panicking))
+		}
+		p.panicking = nil
+	}
+	return r
+}
+
+func builtinClose(ch reflect.Value) (err error) {
+	if ch.IsNil() {
+		return PanicCloseNilChan{}
+	}
+	// Closing a closed channel panics
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError(r)
+		}
+	}()
+	ch.Close()
+	return nil
+}
+
+func builtinClear(v reflect.Value) {
+	if v.Kind() == reflect.Map {
+		for _, k := range v.MapKeys() {
+			v.SetMapIndex(k, reflect.Value{})
+		}
+	} else {
+		zero := reflect.Zero(v.Type().Elem())
+		for i := 0; i < v.Len(); i += 1 {
+			v.Index(i).Set(zero)
+		}
+	}
+}
+
+func builtinPrint(vs ...reflect.Value) {
+	for _, v := range vs {
+		printValue(PrintOutput, v)
+	}
+}
+
+func builtinPrintln(vs ...reflect.Value) {
+	for i, v := range vs {
+		if i != 0 {
+			io.WriteString(PrintOutput, " ")
+		}
+		printValue(PrintOutput, v)
+	}
+	io.WriteString(PrintOutput, "\n")
+}
+
+// Print v as the go runtime would
+func printValue(w io.Writer, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Bool:
+		fmt.Fprint(w, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fmt.Fprint(w, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		fmt.Fprint(w, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		io.WriteString(w, sprintRuntimeFloat(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		fmt.Fprintf(w, "(%s%si)", sprintRuntimeFloat(real(c)), sprintRuntimeFloat(imag(c)))
+	case reflect.String:
+		io.WriteString(w, v.String())
+	case reflect.Slice:
+		fmt.Fprintf(w, "[%d/%d]%#x", v.Len(), v.Cap(), v.Pointer())
+	case reflect.Interface:
+		// The type and data words of the interface
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		words := (*[2]uintptr)(unsafe.Pointer(p.Pointer()))
+		fmt.Fprintf(w, "(%#x,%#x)", words[0], words[1])
+	default:
+		fmt.Fprintf(w, "%#x", v.Pointer())
+	}
+}
+
+// Format f with the fixed precision exponent notation of the go runtime,
+// such as +1.500000e+000
+func sprintRuntimeFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	}
+	const n = 7
+	var buf [n + 7]byte
+	buf[0] = '+'
+	e := 0
+	if f == 0 {
+		if math.Signbit(f) {
+			buf[0] = '-'
+		}
+	} else {
+		if f < 0 {
+			f = -f
+			buf[0] = '-'
+		}
+		for f >= 10 {
+			e += 1
+			f /= 10
+		}
+		for f < 1 {
+			e -= 1
+			f *= 10
+		}
+		h := 5.0
+		for i := 0; i < n; i += 1 {
+			h /= 10
+		}
+		f += h
+		if f >= 10 {
+			e += 1
+			f /= 10
+		}
+	}
+	for i := 0; i < n; i += 1 {
+		d := int(f)
+		buf[i+2] = byte(d + '0')
+		f -= float64(d)
+		f *= 10
+	}
+	buf[1] = buf[2]
+	buf[2] = '.'
+	buf[n+2] = 'e'
+	buf[n+3] = '+'
+	if e < 0 {
+		e = -e
+		buf[n+3] = '-'
+	}
+	buf[n+4] = byte(e/100 + '0')
+	buf[n+5] = byte(e/10%10 + '0')
+	buf[n+6] = byte(e%10 + '0')
+	return string(buf[:])
+}
+
+func builtinMin(xs ...reflect.Value) reflect.Value {
+	return builtinMinMax(true, xs...)
+}
+
+func builtinMax(xs ...reflect.Value) reflect.Value {
+	return builtinMinMax(false, xs...)
+}
+
+// Returns the least (isMin) or greatest of xs, which share an ordered type.
+// If any float is NaN the result is NaN, and -0.0 is less than 0.0.
+func builtinMinMax(isMin bool, xs ...reflect.Value) reflect.Value {
+	r := xs[0]
+	for _, x := range xs[1:] {
+		var less, greater bool
+		switch x.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			less, greater = x.Int() < r.Int(), x.Int() > r.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			less, greater = x.Uint() < r.Uint(), x.Uint() > r.Uint()
+		case reflect.Float32, reflect.Float64:
+			xf, rf := x.Float(), r.Float()
+			if math.IsNaN(rf) {
+				continue
+			} else if math.IsNaN(xf) {
+				r = x
+				continue
+			} else if xf == rf {
+				less, greater = math.Signbit(xf) && !math.Signbit(rf), !math.Signbit(xf) && math.Signbit(rf)
+			} else {
+				less, greater = xf < rf, xf > rf
+			}
+		case reflect.String:
+			less, greater = x.String() < r.String(), x.String() > r.String()
+		}
+		if isMin && less || !isMin && greater {
+			r = x
+		}
+	}
+	return r
+}
@@ -173,7 +173,7 @@ func checkBinaryExpr(binary *ast.BinaryExpr, env Env) (*BinaryExpr, []error) {
 		// Identical types are always valid, except non comparable structs
 		// and types with can only be compared to nil
                 if unhackType(xt) == unhackType(yt) {
-			if !comparableToNilOnly(xt) && (xk != reflect.Struct || isStructComparable(xt)) {
+			if !comparableToNilOnly(xt) && isStaticTypeComparable(xt) {
 				operandT = xt
 			}
                 } else if xuntyped && attemptBinaryOpConversion(yt) {
@@ -197,14 +197,14 @@ func checkBinaryExpr(binary *ast.BinaryExpr, env Env) (*BinaryExpr, []error) {
 		// one operand is a type that satisfies but is not the the other
 		// operand's type, wrap that node in a type cast. This will only be
 		// used by errors.
-		} else if yk == reflect.Interface && xt.Implements(yt) {
+		} else if yk == reflect.Interface && implements(xt, yt) {
 			operandT = yt
 			if isBooleanOp(op) {
 				errExpr = new(BinaryExpr)
 				*errExpr = *aexpr
 				errExpr.X = wrapConcreteTypeWithInterface(x, yt)
 			}
-		} else if xk == reflect.Interface && yt.Implements(xt) {
+		} else if xk == reflect.Interface && implements(yt, xt) {
 			operandT = xt
 			if isBooleanOp(op) {
 				errExpr = new(BinaryExpr)
@@ -36,6 +36,16 @@ func checkCallBuiltinExpr(call *CallExpr, env Env) (*CallExpr, []error, bool) {
 		call, errs = checkBuiltinDeleteExpr(call, env)
 	case "panic":
 		call, errs = checkBuiltinPanicExpr(call, env)
+	case "recover":
+		call, errs = checkBuiltinRecoverExpr(call, env)
+	case "close":
+		call, errs = checkBuiltinCloseExpr(call, env)
+	case "clear":
+		call, errs = checkBuiltinClearExpr(call, env)
+	case "print", "println":
+		call, errs = checkBuiltinPrintExpr(call, env)
+	case "min", "max":
+		call, errs = checkBuiltinMinMaxExpr(call, env)
 	default:
 		return call, nil, false
 	}
@@ -44,6 +54,16 @@ func checkCallBuiltinExpr(call *CallExpr, env Env) (*CallExpr, []error, bool) {
 	return call, errs, true
 }
 
+// Builtins which may appear in statement context, such as in a go statement.
+// The remaining builtins produce a value which must be used.
+func builtinAllowedInStmt(name string) bool {
+	switch name {
+	case "copy", "delete", "panic", "recover", "close", "clear", "print", "println":
+		return true
+	}
+	return false
+}
+
 func checkBuiltinComplex(call *CallExpr, env Env) (*CallExpr, []error) {
 	var errs []error
 	if call.argNEllipsis = call.Ellipsis != token.NoPos; call.argNEllipsis {
@@ -639,6 +659,216 @@ func checkBuiltinPanicExpr(call *CallExpr, env Env) (*CallExpr, []error) {
 	return call, errs
 }
 
+func checkBuiltinRecoverExpr(call *CallExpr, env Env) (*CallExpr, []error) {
+	var errs []error
+	if call.argNEllipsis = call.Ellipsis != token.NoPos; call.argNEllipsis {
+		errs = append(errs, ErrBuiltinInvalidEllipsis{call})
+	}
+	call.knownType = knownType{emptyInterface}
+	if len(call.CallExpr.Args) != 0 {
+		fakeCheckRemainingArgs(call, 0, env)
+		return call, append(errs, ErrBuiltinWrongNumberOfArgs{call})
+	}
+	return call, errs
+}
+
+func checkBuiltinCloseExpr(call *CallExpr, env Env) (*CallExpr, []error) {
+	var errs []error
+	if call.argNEllipsis = call.Ellipsis != token.NoPos; call.argNEllipsis {
+		errs = append(errs, ErrBuiltinInvalidEllipsis{call})
+	}
+	if len(call.CallExpr.Args) != 1 {
+		fakeCheckRemainingArgs(call, 0, env)
+		return call, append(errs, ErrBuiltinWrongNumberOfArgs{call})
+	}
+	x, moreErrs := CheckExpr(call.CallExpr.Args[0], env)
+	errs = append(errs, moreErrs...)
+	call.Args = []Expr{x}
+	if moreErrs != nil && !x.IsConst() {
+		return call, errs
+	}
+	if xt, err := expectSingleType(x); err != nil {
+		errs = append(errs, err)
+	} else if xt == ConstNil {
+		errs = append(errs, ErrUntypedNil{x})
+	} else if xt.Kind() != reflect.Chan {
+		errs = append(errs, ErrCloseNonChan{x, call})
+	} else if xt.ChanDir() & reflect.SendDir == 0 {
+		errs = append(errs, ErrCloseRecvOnlyChan{x, call})
+	}
+	return call, errs
+}
+
+func checkBuiltinClearExpr(call *CallExpr, env Env) (*CallExpr, []error) {
+	var errs []error
+	if call.argNEllipsis = call.Ellipsis != token.NoPos; call.argNEllipsis {
+		errs = append(errs, ErrBuiltinInvalidEllipsis{call})
+	}
+	if len(call.CallExpr.Args) != 1 {
+		fakeCheckRemainingArgs(call, 0, env)
+		return call, append(errs, ErrBuiltinWrongNumberOfArgs{call})
+	}
+	x, moreErrs := CheckExpr(call.CallExpr.Args[0], env)
+	errs = append(errs, moreErrs...)
+	call.Args = []Expr{x}
+	if moreErrs != nil && !x.IsConst() {
+		return call, errs
+	}
+	if xt, err := expectSingleType(x); err != nil {
+		errs = append(errs, err)
+	} else if xt == ConstNil {
+		errs = append(errs, ErrUntypedNil{x})
+	} else if xt.Kind() != reflect.Map && xt.Kind() != reflect.Slice {
+		errs = append(errs, ErrClearNonMapOrSlice{x, call})
+	}
+	return call, errs
+}
+
+func checkBuiltinPrintExpr(call *CallExpr, env Env) (*CallExpr, []error) {
+	var errs []error
+	if call.argNEllipsis = call.Ellipsis != token.NoPos; call.argNEllipsis {
+		errs = append(errs, ErrBuiltinInvalidEllipsis{call})
+	}
+	call.Args = make([]Expr, len(call.CallExpr.Args))
+	for i, arg := range call.CallExpr.Args {
+		x, moreErrs := CheckExpr(arg, env)
+		errs = append(errs, moreErrs...)
+		call.Args[i] = x
+		if moreErrs != nil && !x.IsConst() {
+			continue
+		}
+		if xt, err := expectSingleType(x); err != nil {
+			errs = append(errs, err)
+		} else if xt == ConstNil {
+			errs = append(errs, ErrUntypedNil{x})
+		} else if k := xt.Kind(); k == reflect.Struct || k == reflect.Array {
+			errs = append(errs, ErrPrintUnprintable{x, call})
+		}
+	}
+	return call, errs
+}
+
+// min and max of constants are constant. Untyped arguments take the type
+// of the typed arguments, which must all be identical.
+func checkBuiltinMinMaxExpr(call *CallExpr, env Env) (*CallExpr, []error) {
+	var errs []error
+	if call.argNEllipsis = call.Ellipsis != token.NoPos; call.argNEllipsis {
+		errs = append(errs, ErrBuiltinInvalidEllipsis{call})
+	}
+	if len(call.CallExpr.Args) == 0 {
+		return call, append(errs, ErrBuiltinWrongNumberOfArgs{call})
+	}
+
+	ok := true
+	isConst := true
+	var t, ct reflect.Type
+	call.Args = make([]Expr, len(call.CallExpr.Args))
+	for i, arg := range call.CallExpr.Args {
+		x, moreErrs := CheckExpr(arg, env)
+		errs = append(errs, moreErrs...)
+		call.Args[i] = x
+		if moreErrs != nil && !x.IsConst() {
+			ok = false
+			continue
+		}
+		isConst = isConst && x.IsConst()
+		xt, err := expectSingleType(x)
+		if err != nil {
+			errs = append(errs, err)
+			ok = false
+		} else if xt == ConstNil {
+			errs = append(errs, ErrUntypedNil{x})
+			ok = false
+		} else if !isOpDefinedOn(token.LSS, xt) {
+			errs = append(errs, ErrMinMaxUnordered{x, call})
+			ok = false
+		} else if xct, untyped := xt.(ConstType); untyped {
+			if xct == ConstShiftedInt {
+				xct = ConstInt
+			}
+			// Untyped strings and numbers do not mix
+			if ct == nil {
+				ct = xct
+			} else if (ct == ConstString) != (xct == ConstString) {
+				errs = append(errs, ErrBuiltinMismatchedArgs{call, ct, xct})
+				ok = false
+			} else if xct != ConstString {
+				ct = promoteConstNumbers(ct.(ConstType), xct)
+			}
+		} else if t == nil {
+			t = xt
+		} else if t != xt {
+			errs = append(errs, ErrBuiltinMismatchedArgs{call, t, xt})
+			ok = false
+		}
+	}
+	if !ok {
+		return call, errs
+	}
+
+	if t == nil {
+		call.knownType = knownType{ct}
+		if isConst {
+			call.constValue = evalConstUntypedMinMax(call, ct == ConstString)
+		}
+		return call, errs
+	}
+
+	call.knownType = knownType{t}
+	if ct != nil {
+		if ct == ConstString && t.Kind() != reflect.String ||
+			ct != ConstString && t.Kind() == reflect.String {
+			return call, append(errs, ErrBuiltinMismatchedArgs{call, t, ct})
+		}
+	}
+	if !isConst {
+		for _, x := range call.Args {
+			if xct, ok := x.KnownType()[0].(ConstType); ok {
+				_, moreErrs := promoteConstToTyped(xct, constValue(x.Const()), t, x)
+				errs = append(errs, moreErrs...)
+			}
+		}
+		return call, errs
+	}
+
+	// All arguments are constant, at least one of which is typed
+	xs := make([]reflect.Value, len(call.Args))
+	for i, x := range call.Args {
+		if xct, ok := x.KnownType()[0].(ConstType); ok {
+			c, moreErrs := promoteConstToTyped(xct, constValue(x.Const()), t, x)
+			errs = append(errs, moreErrs...)
+			if xs[i] = reflect.Value(c); !xs[i].IsValid() {
+				return call, errs
+			}
+		} else {
+			xs[i] = x.Const()
+		}
+	}
+	call.constValue = constValue(builtinMinMax(call.CallExpr.Fun.(*ast.Ident).Name == "min", xs...))
+	return call, errs
+}
+
+func evalConstUntypedMinMax(call *CallExpr, isString bool) constValue {
+	isMin := call.CallExpr.Fun.(*ast.Ident).Name == "min"
+	if isString {
+		r := call.Args[0].Const().String()
+		for _, x := range call.Args[1:] {
+			if s := x.Const().String(); s < r == isMin && s != r {
+				r = s
+			}
+		}
+		return constValueOf(r)
+	}
+	r := call.Args[0].Const().Interface().(*ConstNumber)
+	for _, x := range call.Args[1:] {
+		n := x.Const().Interface().(*ConstNumber)
+		if cmp := n.Value.Re.Cmp(&r.Value.Re); cmp < 0 && isMin || cmp > 0 && !isMin {
+			r = n
+		}
+	}
+	return constValueOf(&ConstNumber{Value: r.Value, Type: call.knownType[0].(ConstType)})
+}
+
 func fakeCheckRemainingArgs(call *CallExpr, from int, env Env) {
 	call.Args = append(call.Args[:from], make([]Expr, len(call.CallExpr.Args)-from)...)
 	for i := from; i < len(call.Args); i += 1 {
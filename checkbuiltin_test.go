@@ -0,0 +1,38 @@
+package eval
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckBuiltinClose(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Vars["r"] = reflect.ValueOf(new(<-chan int))
+	expectCheckError(t, "close(1)", env, "invalid operation: close(1) (non-chan type int)")
+	expectCheckError(t, "close(r)", env, "invalid operation: close(r) (cannot close receive-only channel)")
+	expectCheckError(t, "close()", env, "missing argument to close: close()")
+}
+
+func TestCheckBuiltinClear(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectCheckError(t, "clear(\"a\")", env, "invalid argument \"a\" (type string) for clear: argument must be map or slice")
+	expectCheckError(t, "clear(nil)", env, "use of untyped nil")
+}
+
+func TestCheckBuiltinPrint(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectCheckError(t, "print(struct{}{})", env, "illegal types for operand: print\n\tstruct {}")
+	expectCheckError(t, "println(1, nil)", env, "use of untyped nil")
+}
+
+func TestCheckBuiltinMinMax(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Vars["x"] = reflect.ValueOf(new(int))
+	env.Vars["f"] = reflect.ValueOf(new(float64))
+	expectCheckError(t, "min()", env, "not enough arguments for min() (expected 1, found 0)")
+	expectCheckError(t, "max(1, true)", env, "invalid argument true (type bool) for max: cannot be ordered")
+	expectCheckError(t, "min(1i)", env, "invalid argument 1i (type complex128) for min: cannot be ordered")
+	expectCheckError(t, "min(x, f)", env, "invalid operation: min(x, f) (mismatched types int and float64)")
+	expectCheckError(t, "max(1, \"a\")", env, "invalid operation: max(1, \"a\") (mismatched types untyped number and untyped string)")
+	expectCheckError(t, "max(x, 1.5)", env, "constant 1.5 truncated to integer")
+}
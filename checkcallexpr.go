@@ -80,7 +80,7 @@ func checkCallTypeExpr(call *CallExpr, to reflect.Type, env Env) (acall *CallExp
 		}
 		return call, errs
 	} else {
-		if from.ConvertibleTo(to) {
+		if typeConvertibleTo(from, to) {
 			if arg.IsConst() {
 				call.constValue = constValue(arg.Const().Convert(to))
 			}
@@ -92,7 +92,13 @@ func checkCallTypeExpr(call *CallExpr, to reflect.Type, env Env) (acall *CallExp
 }
 
 func checkCallFunExpr(call *CallExpr, env Env) (*CallExpr, []error) {
-	fun, errs := CheckExpr(call.CallExpr.Fun, env)
+	var fun Expr
+	var errs []error
+	if generic, moreErrs, isGeneric := checkGenericCallFun(call, env); isGeneric {
+		fun, errs = generic, moreErrs
+	} else {
+		fun, errs = CheckExpr(call.CallExpr.Fun, env)
+	}
 	if errs != nil && !fun.IsConst() {
 		return call, errs
 	}
@@ -17,7 +17,9 @@ func checkCompositeLitR(lit *ast.CompositeLit, t reflect.Type, env Env) (*Compos
 
 	// We won't generate any errors here if the given type does not match lit.Type.
 	// The caller will need to detect the type incompatibility.
-	if lit.Type != nil {
+	if array, ok := lit.Type.(*ast.ArrayType); ok && isEllipsis(array.Len) {
+		return checkCompositeLitEllipsisArray(alit, array, env)
+	} else if lit.Type != nil {
 		var errs []error
 		lit.Type, t, _, errs = checkType(lit.Type, env)
 		if errs != nil {
@@ -44,6 +46,28 @@ func checkCompositeLitR(lit *ast.CompositeLit, t reflect.Type, env Env) (*Compos
 	}
 }
 
+// Check a [...]T{} literal. The elements are checked as if the literal were
+// a []T{}, after which the array length is known.
+func checkCompositeLitEllipsisArray(lit *CompositeLit, array *ast.ArrayType, env Env) (*CompositeLit, []error) {
+	elt, eltT, _, errs := checkType(array.Elt, env)
+	if errs != nil {
+		return lit, errs
+	}
+	arrayT := &ArrayType{ArrayType: array, Len: &Ellipsis{Ellipsis: array.Len.(*ast.Ellipsis)}, Elt: elt}
+	lit.CompositeLit.Type = arrayT
+	if lit.CompositeLit.Elts != nil {
+		lit.Elts = make([]Expr, len(lit.CompositeLit.Elts))
+	}
+	lit, errs = checkCompositeLitArrayOrSlice(lit, reflect.SliceOf(unhackType(eltT)), env)
+	if errs != nil {
+		return lit, errs
+	}
+	t := reflect.ArrayOf(lit.length, unhackType(eltT))
+	arrayT.knownType = knownType{t}
+	lit.knownType = knownType{t}
+	return lit, nil
+}
+
 func checkCompositeLitMap(lit *CompositeLit, t reflect.Type, env Env) (*CompositeLit, []error) {
 	var errs []error
 	kT := t.Key()
@@ -226,7 +250,8 @@ func checkCompositeLitStruct(lit *CompositeLit, t reflect.Type, env Env) (*Compo
 				errs = append(errs, ErrInvalidStructField{key})
 			} else {
 				name := ident.Name
-				if field, ok := t.FieldByName(name); !ok {
+				// Promoted fields cannot be used in composite literals
+				if field, ok := t.FieldByName(name); !ok || len(field.Index) != 1 {
 					value = fakeCheckExpr(kv.Value, env)
 					errs = append(errs, ErrUnknownStructField{key, t, name})
 				} else {
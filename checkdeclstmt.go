@@ -0,0 +1,349 @@
+package eval
+
+import (
+	"errors"
+	"reflect"
+
+	"go/ast"
+	"go/token"
+)
+
+// Check a var, const, type, import or method declaration. Declared identifiers are
+// added to env as they are checked, so that later specs may refer to earlier ones.
+func checkDeclStmt(decl *ast.DeclStmt, env Env) (*DeclStmt, []error) {
+	adecl := &DeclStmt{DeclStmt: decl}
+	if fun, ok := decl.Decl.(*ast.FuncDecl); ok && fun.Recv != nil {
+		var errs []error
+		adecl.Method, errs = checkMethodDecl(fun, env)
+		return adecl, errs
+	}
+	gen, ok := decl.Decl.(*ast.GenDecl)
+	if !ok {
+		return adecl, []error{errors.New("Only var, const and type declarations are supported")}
+	}
+
+	var errs, moreErrs []error
+	adecl.Specs = make([]Spec, len(gen.Specs))
+	switch gen.Tok {
+	case token.CONST:
+		// Specs without types or values repeat those of the previous spec
+		var typ ast.Expr
+		var values []ast.Expr
+		for iota, spec := range gen.Specs {
+			vspec := spec.(*ast.ValueSpec)
+			if vspec.Type != nil || vspec.Values != nil {
+				typ, values = vspec.Type, vspec.Values
+			}
+			adecl.Specs[iota], moreErrs = checkConstSpec(vspec, typ, values, iota, env)
+			errs = append(errs, moreErrs...)
+		}
+	case token.VAR:
+		for i, spec := range gen.Specs {
+			adecl.Specs[i], moreErrs = checkVarSpec(spec.(*ast.ValueSpec), env)
+			errs = append(errs, moreErrs...)
+		}
+	case token.TYPE:
+		for i, spec := range gen.Specs {
+			adecl.Specs[i], moreErrs = checkTypeSpec(spec.(*ast.TypeSpec), env)
+			errs = append(errs, moreErrs...)
+		}
+	case token.IMPORT:
+		for i, spec := range gen.Specs {
+			adecl.Specs[i], moreErrs = checkImportSpec(spec.(*ast.ImportSpec), env)
+			errs = append(errs, moreErrs...)
+		}
+	default:
+		return adecl, []error{errors.New("Only var, const and type declarations are supported")}
+	}
+	return adecl, errs
+}
+
+func checkConstSpec(spec *ast.ValueSpec, typ ast.Expr, values []ast.Expr, iota int, env Env) (*ValueSpec, []error) {
+	aspec := &ValueSpec{ValueSpec: spec, tok: token.CONST}
+	aspec.Names = make([]*Ident, len(spec.Names))
+	aspec.types = make([]reflect.Type, len(spec.Names))
+	aspec.consts = make([]reflect.Value, len(spec.Names))
+	for i, name := range spec.Names {
+		aspec.Names[i] = &Ident{Ident: name}
+	}
+
+	var errs []error
+	var t reflect.Type
+	if typ != nil {
+		var isType bool
+		var moreErrs []error
+		aspec.Type, t, isType, moreErrs = checkType(typ, env)
+		errs = append(errs, moreErrs...)
+		if !isType {
+			if moreErrs == nil {
+				errs = append(errs, ErrBuiltinNonTypeArg{fakeCheckExpr(typ, env)})
+			}
+			return aspec, errs
+		} else if t == nil {
+			return aspec, errs
+		}
+	}
+
+	if len(values) != len(spec.Names) {
+		errs = append(errs, ErrDeclCountMismatch{aspec, len(spec.Names), len(values)})
+	}
+
+	// iota is only defined within const declarations
+	iotaEnv := env.PushScope()
+	iotaEnv.AddConst("iota", reflect.ValueOf(NewConstInt64(int64(iota))))
+
+	aspec.Values = make([]Expr, len(values))
+	for i, value := range values {
+		v, moreErrs := CheckExpr(value, iotaEnv)
+		aspec.Values[i] = v
+		errs = append(errs, moreErrs...)
+		if moreErrs != nil && !v.IsConst() {
+			continue
+		} else if !v.IsConst() || v.KnownType()[0] == ConstNil {
+			errs = append(errs, ErrNonConstInitializer{v})
+			continue
+		} else if i >= len(spec.Names) {
+			continue
+		}
+
+		c := v.Const()
+		vt := v.KnownType()[0]
+		if t == nil {
+			// The const is looked up using the ConstNumber's type,
+			// which may differ from the type of the expression.
+			if n, ok := c.Interface().(*ConstNumber); ok && n.Type != vt {
+				c = reflect.ValueOf(&ConstNumber{Value: n.Value, Type: vt.(ConstType)})
+			}
+			aspec.types[i] = vt
+		} else if ct, ok := vt.(ConstType); ok {
+			cv, moreErrs := promoteConstToTyped(ct, constValue(c), t, v)
+			errs = append(errs, moreErrs...)
+			if c = reflect.Value(cv); !c.IsValid() {
+				continue
+			}
+			aspec.types[i] = t
+		} else if !typeAssignableTo(vt, t) {
+			errs = append(errs, ErrBadDeclValue{v, t})
+			continue
+		} else {
+			aspec.types[i] = t
+		}
+		// Consts of Named types are boxed, so that their type may be recovered
+		c = boxNamed(c, aspec.types[i])
+		aspec.consts[i] = c
+		if name := spec.Names[i].Name; name != "_" {
+			env.AddConst(name, c)
+		}
+	}
+	return aspec, errs
+}
+
+func checkVarSpec(spec *ast.ValueSpec, env Env) (*ValueSpec, []error) {
+	aspec := &ValueSpec{ValueSpec: spec, tok: token.VAR}
+	aspec.Names = make([]*Ident, len(spec.Names))
+	aspec.types = make([]reflect.Type, len(spec.Names))
+	for i, name := range spec.Names {
+		aspec.Names[i] = &Ident{Ident: name}
+	}
+
+	var errs, moreErrs []error
+	var t reflect.Type
+	if spec.Type != nil {
+		var isType bool
+		aspec.Type, t, isType, moreErrs = checkType(spec.Type, env)
+		errs = append(errs, moreErrs...)
+		if !isType && moreErrs == nil {
+			errs = append(errs, ErrBuiltinNonTypeArg{fakeCheckExpr(spec.Type, env)})
+		}
+		if t == nil {
+			return aspec, errs
+		}
+	}
+
+	if spec.Values != nil {
+		aspec.Values = make([]Expr, len(spec.Values))
+	}
+	if len(spec.Values) == 0 {
+		for i := range aspec.types {
+			aspec.types[i] = t
+		}
+	} else if len(spec.Values) == 1 && len(spec.Names) > 1 {
+		// var a, b = f() or var v, ok = m[k]
+		v, moreErrs := CheckExpr(spec.Values[0], env)
+		aspec.Values[0] = v
+		if moreErrs != nil {
+			return aspec, append(errs, moreErrs...)
+		}
+		kt := append([]reflect.Type{}, v.KnownType()...)
+		if len(spec.Names) == 2 && multivalueOk(v) {
+			kt = append(kt, boolType)
+		}
+		if len(kt) != len(spec.Names) {
+			return aspec, append(errs, ErrDeclCountMismatch{aspec, len(spec.Names), len(kt)})
+		}
+		for i := range kt {
+			if t == nil {
+				aspec.types[i] = kt[i]
+			} else if typeAssignableTo(kt[i], t) {
+				aspec.types[i] = t
+			} else {
+				errs = append(errs, ErrBadDeclValue{v, t})
+			}
+		}
+	} else {
+		if len(spec.Values) != len(spec.Names) {
+			errs = append(errs, ErrDeclCountMismatch{aspec, len(spec.Names), len(spec.Values)})
+		}
+		for i, value := range spec.Values {
+			var v Expr
+			if t != nil {
+				var ok bool
+				v, ok, moreErrs = checkExprAssignableTo(value, t, env)
+				errs = append(errs, moreErrs...)
+				if ok && moreErrs == nil {
+					ok = namedExprAssignableTo(v, t)
+				}
+				if !ok {
+					errs = append(errs, ErrBadDeclValue{v, t})
+				} else if i < len(aspec.types) {
+					aspec.types[i] = t
+				}
+			} else {
+				v, moreErrs = CheckExpr(value, env)
+				errs = append(errs, moreErrs...)
+				if moreErrs == nil || v.IsConst() {
+					if vt, err := expectSingleType(v); err != nil {
+						errs = append(errs, err)
+					} else if vt == ConstNil {
+						errs = append(errs, ErrUntypedNil{v})
+					} else if i < len(aspec.types) {
+						aspec.types[i] = defaultPromotion(vt)
+					}
+				}
+			}
+			aspec.Values[i] = v
+		}
+	}
+
+	// Names are only in scope after the spec, var x = x refers to an outer x
+	for i, name := range spec.Names {
+		if name.Name != "_" && aspec.types[i] != nil {
+			env.AddVar(name.Name, newVar(aspec.types[i]))
+		}
+	}
+	return aspec, errs
+}
+
+func checkTypeSpec(spec *ast.TypeSpec, env Env) (*TypeSpec, []error) {
+	aspec := &TypeSpec{TypeSpec: spec, Name: &Ident{Ident: spec.Name}}
+	if spec.TypeParams != nil {
+		return aspec, []error{errors.New("generic types not implemented")}
+	}
+	typ, t, isType, errs := checkType(spec.Type, env)
+	if !isType {
+		typ = fakeCheckExpr(spec.Type, env)
+		if errs == nil {
+			errs = append(errs, ErrBuiltinNonTypeArg{typ})
+		}
+	}
+	aspec.Type = typ
+	if t != nil {
+		// Interfaces cannot have methods, so a declared interface
+		// type refers to the interface type itself.
+		if t.Kind() != reflect.Interface {
+			t = NewNamed(spec.Name.Name, t)
+		}
+		aspec.Name.knownType = knownType{t}
+		if spec.Name.Name != "_" {
+			env.AddType(spec.Name.Name, t)
+		}
+	}
+	return aspec, errs
+}
+
+// Check a method declaration. The method is declared on its receiver type
+// before the body is checked, so that the body may call it. The receiver
+// type must be a Named T or its pointer type *T.
+func checkMethodDecl(decl *ast.FuncDecl, env Env) (*FuncDecl, []error) {
+	adecl, ft, errs := checkMethodSignature(decl, env)
+	if adecl.Lit == nil {
+		return adecl, errs
+	}
+	return checkMethodBody(adecl, ft, errs, env)
+}
+
+// Check the receiver and signature of a method declaration, and declare
+// the method. The Lit of the returned FuncDecl is nil if the receiver is
+// invalid.
+func checkMethodSignature(decl *ast.FuncDecl, env Env) (*FuncDecl, reflect.Type, []error) {
+	adecl := &FuncDecl{FuncDecl: decl}
+	if len(decl.Recv.List) != 1 || len(decl.Recv.List[0].Names) > 1 {
+		return adecl, nil, []error{errors.New("method has multiple receivers")}
+	} else if decl.Body == nil {
+		return adecl, nil, []error{errors.New("missing function body")}
+	}
+
+	recv := decl.Recv.List[0]
+	typ, t, isType, errs := checkType(recv.Type, env)
+	if !isType {
+		if errs == nil {
+			errs = append(errs, ErrBuiltinNonTypeArg{fakeCheckExpr(recv.Type, env)})
+		}
+		return adecl, nil, errs
+	} else if t == nil {
+		return adecl, nil, errs
+	}
+
+	named, ok := t.(*Named)
+	ptrRecv := ok && named.elem != nil
+	if ptrRecv {
+		named = named.elem
+	}
+	if !ok {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Ptr || t.Kind() == reflect.Interface {
+			return adecl, nil, []error{ErrInvalidReceiver{typ}}
+		}
+		return adecl, nil, []error{ErrNonLocalReceiver{typ}}
+	} else if k := named.Kind(); k == reflect.Ptr || k == reflect.Interface {
+		return adecl, nil, []error{ErrInvalidReceiver{typ}}
+	}
+
+	params := &ast.FieldList{
+		Opening: decl.Type.Params.Opening,
+		List: append([]*ast.Field{recv}, decl.Type.Params.List...),
+		Closing: decl.Type.Params.Closing,
+	}
+	lit := &ast.FuncLit{
+		Type: &ast.FuncType{Func: decl.Type.Func, Params: params, Results: decl.Type.Results},
+		Body: decl.Body,
+	}
+	alit, ft, errs := checkFuncLitType(lit, env)
+	adecl.Lit = alit
+	adecl.recv = named
+	name := &Ident{Ident: decl.Name}
+	if ft != nil && name.Name != "_" {
+		if adecl.method = named.addMethod(name.Name, ft, ptrRecv); adecl.method == nil {
+			errs = append(errs, ErrDuplicateMethod{name, name.Name})
+		} else if named.Kind() == reflect.Struct {
+			if _, ok := named.FieldByName(name.Name); ok {
+				errs = append(errs, ErrFieldAndMethod{name, named})
+			}
+		}
+	}
+	return adecl, ft, errs
+}
+
+// Check the body of a method declared by checkMethodSignature, where errs
+// are the errors of checking the signature. The method is removed from its
+// receiver type if any errors occur.
+func checkMethodBody(adecl *FuncDecl, ft reflect.Type, errs []error, env Env) (*FuncDecl, []error) {
+	adecl.Lit, errs = checkFuncLitBody(adecl.Lit, ft, errs, env)
+	if errs != nil && adecl.method != nil {
+		adecl.recv.removeMethod(adecl.method)
+		adecl.method = nil
+	}
+	return adecl, errs
+}
@@ -2,7 +2,9 @@ package eval
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
+	"strconv"
 	"go/ast"
 )
 
@@ -40,7 +42,12 @@ func CheckExpr(expr ast.Expr, env Env) (Expr, []error) {
 	case *ast.SelectorExpr:
 		return checkSelectorExpr(expr, env)
 	case *ast.IndexExpr:
+		if g, _ := lookupGeneric(expr.X, env); g != nil {
+			return checkIndexListExpr(indexListExpr(expr), env)
+		}
 		return checkIndexExpr(expr, env)
+	case *ast.IndexListExpr:
+		return checkIndexListExpr(expr, env)
 	case *ast.SliceExpr:
 		return checkSliceExpr(expr, env)
 	case *ast.TypeAssertExpr:
@@ -67,12 +74,17 @@ func checkType(expr ast.Expr, env Env) (Expr, reflect.Type, bool, []error) {
 	switch node := expr.(type) {
 	case *ast.Ident:
 		ident := &Ident{Ident: node}
-		if t := env.Type(node.Name); t != nil {
+		if t := lookupType(node.Name, env); t != nil {
 			ident.knownType = knownType{t}
 			return ident, t, true, nil
 		} else if t, ok := builtinTypes[node.Name]; ok {
 			ident.knownType = knownType{t}
 			return ident, t, true, nil
+		} else if g, _ := lookupGeneric(node, env); g != nil && g.isType {
+			return ident, nil, true, []error{ErrUninstantiatedGeneric{ident, g}}
+		} else if node.Name == "comparable" {
+			// comparable is only valid as a type constraint
+			return ident, nil, true, []error{ErrComparableOutsideConstraint{ident}}
 		} else {
 			return ident, nil, false, []error{ErrUndefined{ident}}
 		}
@@ -81,7 +93,7 @@ func checkType(expr ast.Expr, env Env) (Expr, reflect.Type, bool, []error) {
 		elem, elemT, isType, errs := checkType(node.X, env)
 		if isType {
 			// Only set X if X is a type, as * can be part of an expression or type
-			t := reflect.PtrTo(elemT)
+			t := ptrTo(elemT)
 			star.X = elem
 			star.knownType = knownType{t}
 			return star, t, isType, nil
@@ -105,10 +117,38 @@ func checkType(expr ast.Expr, env Env) (Expr, reflect.Type, bool, []error) {
 			sel.knownType = knownType{t}
 			return sel, t, true, nil
 		}
+	case *ast.IndexExpr:
+		if index, t, isType, errs := checkGenericType(indexListExpr(node), env); isType {
+			return index, t, isType, errs
+		}
+	case *ast.IndexListExpr:
+		if index, t, isType, errs := checkGenericType(node, env); isType {
+			return index, t, isType, errs
+		}
 	case *ast.ArrayType:
 		arrayT := &ArrayType{ArrayType: node}
-		if node.Len != nil {
-			return arrayT, nil, true, []error{errors.New("array types not implemented")}
+		if isEllipsis(node.Len) {
+			// [...]T is only valid as the type of a composite literal
+			// and is handled by checkCompositeLit
+			arrayT.Len = &Ellipsis{Ellipsis: node.Len.(*ast.Ellipsis)}
+			arrayT.Elt, _, _, _ = checkType(node.Elt, env)
+			return arrayT, nil, true, []error{ErrArrayEllipsisOutsideLit{arrayT}}
+		} else if node.Len != nil {
+			var length int
+			var errs []error
+			arrayT.Len, length, errs = checkArrayLen(node.Len, env)
+			elt, eltT, _, moreErrs := checkType(node.Elt, env);
+			arrayT.Elt = elt
+			errs = append(errs, moreErrs...)
+			if errs != nil {
+				return arrayT, nil, true, errs
+			} else if size := unhackType(eltT).Size(); size != 0 && uintptr(length) > maxAlloc/size {
+				return arrayT, nil, true, []error{ErrArrayBoundTooLarge{arrayT.Len}}
+			} else {
+				t := reflect.ArrayOf(length, unhackType(eltT))
+				arrayT.knownType = knownType{t}
+				return arrayT, t, true, nil
+			}
 		} else {
 			elt, eltT, _, errs := checkType(node.Elt, env);
 			arrayT.Elt = elt
@@ -121,19 +161,14 @@ func checkType(expr ast.Expr, env Env) (Expr, reflect.Type, bool, []error) {
 			}
 		}
 	case *ast.StructType:
-		structT := &StructType{StructType: node}
-		return structT, nil, true, []error{errors.New("struct types not implemented")}
+		structT, t, errs := checkStructType(node, env)
+		return structT, t, true, errs
 	case *ast.FuncType:
-		funcT := &FuncType{FuncType: node}
-		return funcT, nil, true, []error{errors.New("func types not implemented")}
+		funcT, t, errs := checkFuncType(node, env)
+		return funcT, t, true, errs
 	case *ast.InterfaceType:
-		interfaceT := &InterfaceType{InterfaceType: node}
-		// Allow interface{}'s
-		if node.Methods.List == nil {
-			interfaceT.knownType = knownType{emptyInterface}
-			return interfaceT, emptyInterface, true, nil
-		}
-		return interfaceT, nil, true, []error{errors.New("interface types not implemented")}
+		interfaceT, t, errs := checkInterfaceType(node, env)
+		return interfaceT, t, true, errs
 	case *ast.MapType:
 		mapT := &MapType{MapType: node}
 		keyT, k, _, errs := checkType(node.Key, env)
@@ -174,6 +209,19 @@ func checkType(expr ast.Expr, env Env) (Expr, reflect.Type, bool, []error) {
 	return nil, nil, false, nil
 }
 
+// Returns the type ident from the innermost scope of env declaring ident,
+// or nil if that declaration is not a type.
+func lookupType(ident string, env Env) reflect.Type {
+	for ; env != nil; env = env.PopScope() {
+		if t := env.Type(ident); t != nil {
+			return t
+		} else if env.Var(ident).IsValid() || env.Func(ident).IsValid() || env.Const(ident).IsValid() {
+			return nil
+		}
+	}
+	return nil
+}
+
 func checkFieldList(list *ast.FieldList, env Env) (*FieldList, []error) {
 	if list == nil {
 		return nil, nil
@@ -189,7 +237,7 @@ func checkFieldList(list *ast.FieldList, env Env) (*FieldList, []error) {
 		alist.List [i], moreErrs = checkField(field, env)
 		errs = append(errs, moreErrs...)
 	}
-	return alist, nil
+	return alist, errs
 }
 
 func checkField(field *ast.Field, env Env) (*Field, []error) {
@@ -207,7 +255,7 @@ func checkField(field *ast.Field, env Env) (*Field, []error) {
 	if ellipsis, ok := field.Type.(*ast.Ellipsis); ok {
 		typ, t, _, errs = checkType(ellipsis.Elt, env)
 		if t != nil {
-			t = reflect.SliceOf(t)
+			t = reflect.SliceOf(unhackType(t))
 		}
 		ellipsis.Elt = typ
 		typ = &Ellipsis{Ellipsis: ellipsis}
@@ -220,3 +268,227 @@ func checkField(field *ast.Field, env Env) (*Field, []error) {
 	}
 	return afield, errs
 }
+
+func checkStructType(node *ast.StructType, env Env) (*StructType, reflect.Type, []error) {
+	structT := &StructType{StructType: node}
+	fields, errs := checkFieldList(node.Fields, env)
+	structT.Fields = fields
+	if errs != nil {
+		return structT, nil, errs
+	}
+
+	var sfields []reflect.StructField
+	seen := map[string]bool{}
+	for _, field := range fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			s, _ := strconv.Unquote(field.Tag.Value)
+			tag = reflect.StructTag(s)
+		}
+		t := unhackType(field.KnownType()[0])
+		names := field.Names
+		anonymous := names == nil
+		if anonymous {
+			// Embedded fields are named after their type, excluding the package and *
+			name, ok := embeddedFieldName(field.Type)
+			if t.Kind() == reflect.Ptr {
+				ok = ok && t.Elem().Kind() != reflect.Ptr && t.Elem().Kind() != reflect.Interface
+			}
+			if !ok {
+				errs = append(errs, ErrInvalidEmbeddedField{field.Type})
+				continue
+			}
+			if !ast.IsExported(name.Name) {
+				// reflect cannot embed a field without setting its PkgPath
+				errs = append(errs, ErrUnexportedEmbeddedField{field.Type})
+				continue
+			}
+			names = []*Ident{name}
+		}
+		for _, ident := range names {
+			name := ident.Name
+			if name != "_" && seen[name] {
+				errs = append(errs, ErrDuplicateFieldDecl{ident})
+				continue
+			}
+			seen[name] = true
+			sfield := reflect.StructField{Name: name, Type: t, Tag: tag, Anonymous: anonymous}
+			if !ast.IsExported(name) {
+				sfield.PkgPath = interpPkgPath
+			}
+			sfields = append(sfields, sfield)
+		}
+	}
+	if errs != nil {
+		return structT, nil, errs
+	}
+
+	t, err := structOf(sfields)
+	if err != nil {
+		return structT, nil, []error{err}
+	}
+	structT.knownType = knownType{t}
+	return structT, t, nil
+}
+
+// Calls reflect.StructOf, returning any panic as an error rather than
+// crashing the host.
+func structOf(fields []reflect.StructField) (t reflect.Type, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("struct type not supported by the interpreter: %v", r)
+		}
+	}()
+	return reflect.StructOf(fields), nil
+}
+
+// Check an interface type. Interfaces with methods are represented by an
+// *Interface, as reflect cannot create interface types.
+func checkInterfaceType(node *ast.InterfaceType, env Env) (*InterfaceType, reflect.Type, []error) {
+	interfaceT := &InterfaceType{InterfaceType: node}
+	// Allow interface{}'s
+	if node.Methods.List == nil {
+		interfaceT.knownType = knownType{emptyInterface}
+		return interfaceT, emptyInterface, nil
+	}
+	methods, errs := checkFieldList(node.Methods, env)
+	interfaceT.Methods = methods
+	if errs != nil {
+		return interfaceT, nil, errs
+	}
+
+	var methodSet []reflect.Method
+	seen := map[string]reflect.Type{}
+	add := func(expr Expr, embedded bool, method reflect.Method) {
+		if t, ok := seen[method.Name]; !ok {
+			seen[method.Name] = method.Type
+			methodSet = append(methodSet, method)
+		} else if !embedded || t != method.Type {
+			// Embedded interfaces may overlap if the signatures are identical
+			errs = append(errs, ErrDuplicateMethod{expr, method.Name})
+		}
+	}
+	for _, field := range methods.List {
+		t := field.KnownType()[0]
+		if field.Names != nil {
+			add(field.Names[0], false, reflect.Method{Name: field.Names[0].Name, Type: t})
+		} else if t.Kind() != reflect.Interface {
+			errs = append(errs, ErrEmbeddedNonInterface{field.Type})
+		} else {
+			for i := 0; i < t.NumMethod(); i += 1 {
+				add(field.Type, true, t.Method(i))
+			}
+		}
+	}
+	if errs != nil {
+		return interfaceT, nil, errs
+	}
+
+	t := NewInterface(methodSet)
+	interfaceT.knownType = knownType{t}
+	return interfaceT, t, nil
+}
+
+// Check a func type. The Params and Results of the returned FuncType are set
+// even if errors occur, so that func literals may add the valid params to scope.
+func checkFuncType(node *ast.FuncType, env Env) (*FuncType, reflect.Type, []error) {
+	funcT := &FuncType{FuncType: node}
+	if node.TypeParams != nil {
+		return funcT, nil, []error{errors.New("generic functions not implemented")}
+	}
+	params, errs := checkFieldList(node.Params, env)
+	results, moreErrs := checkFieldList(node.Results, env)
+	funcT.Params, funcT.Results = params, results
+	errs = append(errs, moreErrs...)
+
+	var in, out []reflect.Type
+	variadic := false
+	for i := 0; i < 2; i += 1 {
+		fields, types := params, &in
+		if i == 1 {
+			fields, types = results, &out
+		}
+		if fields == nil {
+			continue
+		}
+		for j, field := range fields.List {
+			if ellipsis, ok := field.Type.(*Ellipsis); ok {
+				if i == 1 || j != len(fields.List) - 1 || len(field.Names) > 1 {
+					errs = append(errs, ErrBadFuncTypeEllipsis{ellipsis})
+				}
+				variadic = true
+			}
+			if len(field.KnownType()) == 0 {
+				continue
+			}
+			t := unhackType(field.KnownType()[0])
+			*types = append(*types, t)
+			for k := 1; k < len(field.Names); k += 1 {
+				*types = append(*types, t)
+			}
+		}
+	}
+	if errs != nil {
+		return funcT, nil, errs
+	}
+
+	t := reflect.FuncOf(in, out, variadic)
+	funcT.knownType = knownType{t}
+	return funcT, t, nil
+}
+
+// Returns the implicit name of an embedded field, T for both T and *T, and
+// false if typ is not of the form T, *T, pkg.T or *pkg.T.
+func embeddedFieldName(typ Expr) (*Ident, bool) {
+	if star, ok := typ.(*StarExpr); ok {
+		typ = star.X
+	}
+	switch typ := typ.(type) {
+	case *Ident:
+		return typ, true
+	case *SelectorExpr:
+		return typ.Sel, true
+	}
+	return nil, false
+}
+
+// Check the length of an array type, which must be a non-negative integer constant.
+func checkArrayLen(expr ast.Expr, env Env) (Expr, int, []error) {
+	aexpr, errs := CheckExpr(expr, env)
+	if errs != nil && !aexpr.IsConst() {
+		return aexpr, 0, errs
+	} else if !aexpr.IsConst() {
+		return aexpr, 0, append(errs, ErrNonConstArrayBound{aexpr})
+	}
+	var n int64
+	t := aexpr.KnownType()[0]
+	if ct, ok := t.(ConstType); ok {
+		if !ct.IsNumeric() {
+			return aexpr, 0, append(errs, ErrInvalidArrayBound{aexpr})
+		}
+		c, moreErrs := promoteConstToTyped(ct, constValue(aexpr.Const()), intType, aexpr)
+		errs = append(errs, moreErrs...)
+		v := reflect.Value(c)
+		if !v.IsValid() {
+			return aexpr, 0, errs
+		}
+		n = v.Int()
+	} else {
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = aexpr.Const().Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if u := aexpr.Const().Uint(); u > 1<<62 {
+				n = 1<<62
+			} else {
+				n = int64(u)
+			}
+		default:
+			return aexpr, 0, append(errs, ErrInvalidArrayBound{aexpr})
+		}
+	}
+	if n < 0 {
+		return aexpr, 0, append(errs, ErrNegativeArrayBound{aexpr})
+	}
+	return aexpr, int(n), errs
+}
@@ -7,12 +7,25 @@ import (
 )
 
 func checkFuncLit(lit *ast.FuncLit, env Env) (*FuncLit, []error) {
+	alit, t, errs := checkFuncLitType(lit, env)
+	return checkFuncLitBody(alit, t, errs, env)
+}
+
+// Check the type of a func literal, without checking its body.
+func checkFuncLitType(lit *ast.FuncLit, env Env) (*FuncLit, reflect.Type, []error) {
 	alit := &FuncLit{FuncLit: lit}
 	atype, t, _, errs := checkType(lit.Type, env)
 	alit.Type = atype.(*FuncType)
 	if t != nil {
 		alit.knownType = knownType{t}
 	}
+	return alit, t, errs
+}
+
+// Check the body of a func literal of type t, where errs are the errors
+// of checking the type.
+func checkFuncLitBody(alit *FuncLit, t reflect.Type, errs []error, env Env) (*FuncLit, []error) {
+	lit := alit.FuncLit
 
 	// Create a new environment containing all valid params and results.
 	seen := map[string]bool{}
@@ -24,7 +37,7 @@ func checkFuncLit(lit *ast.FuncLit, env Env) (*FuncLit, []error) {
 		for _, field := range fields.List {
 			var z reflect.Value
 			if len(field.KnownType()) != 0 {
-				z = reflect.New(field.KnownType()[0])
+				z = newVar(field.KnownType()[0])
 			}
 			for _, name := range field.Names {
 				if name.Name == "_" {
@@ -45,7 +58,7 @@ func checkFuncLit(lit *ast.FuncLit, env Env) (*FuncLit, []error) {
 	}
 
 	// t may be nil, in this case return statements won't be checked
-	block, moreErrs := checkBlock(lit.Body, env, checkCtx{outerFunc: t})
+	block, moreErrs := checkBlock(lit.Body, env, checkCtx{outerFunc: t, inFunc: true})
 	alit.Body = block
 
 	// Filter out undefined errors caused by invalid params
@@ -58,5 +71,6 @@ func checkFuncLit(lit *ast.FuncLit, env Env) (*FuncLit, []error) {
 		}
 		filtered = append(filtered, err)
 	}
+	filtered = append(filtered, checkBranches(block)...)
 	return alit, append(errs, filtered...)
 }
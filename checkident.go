@@ -22,16 +22,22 @@ func checkIdent(ident *ast.Ident, env Env) (_ *Ident, errs []error) {
         default:
 		for searchEnv := env; searchEnv != nil; searchEnv = searchEnv.PopScope() {
 			if v := searchEnv.Var(aexpr.Name); v.IsValid() {
-				aexpr.knownType = knownType{v.Elem().Type()}
+				aexpr.knownType = knownType{varType(v)}
 				aexpr.source = envVar
 				return aexpr, errs
 			} else if v := searchEnv.Func(aexpr.Name); v.IsValid() {
+				if v.Type() == genericType {
+					return aexpr, append(errs, ErrUninstantiatedGeneric{aexpr, v.Interface().(*Generic)})
+				}
 				aexpr.knownType = knownType{v.Type()}
 				aexpr.source = envFunc
 				return aexpr, errs
 			} else if v := searchEnv.Const(aexpr.Name); v.IsValid() {
 				if n, ok := v.Interface().(*ConstNumber); ok {
 					aexpr.knownType = knownType{n.Type}
+				} else if named := boxedNamed(v.Type()); named != nil {
+					aexpr.knownType = knownType{named}
+					v = v.Field(0)
 				} else {
 					aexpr.knownType = knownType{v.Type()}
 				}
@@ -59,6 +59,9 @@ func checkIndexExpr(index *ast.IndexExpr, env Env) (*IndexExpr, []error) {
 
 func checkIndexVectorExpr(x Expr, index ast.Expr, env Env) (Expr, []error) {
 	t := x.KnownType()[0]
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
 	i, iint, ok, errs := checkInteger(index, env)
 	if errs != nil && !i.IsConst() {
 		// Type check of index failed
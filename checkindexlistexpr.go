@@ -0,0 +1,211 @@
+package eval
+
+import (
+	"reflect"
+	"go/ast"
+	"go/token"
+)
+
+// Check the instantiation F[T1, T2] of a generic func outside of a call,
+// where every type argument must be given.
+func checkIndexListExpr(index *ast.IndexListExpr, env Env) (*IndexListExpr, []error) {
+	aexpr, g, targs, errs := checkInstantiation(index, env)
+	if errs != nil {
+		return aexpr, errs
+	} else if g.isType {
+		return aexpr, []error{ErrTypeUsedAsExpression{aexpr}}
+	} else if len(targs) != g.numTypeParams() {
+		return aexpr, []error{ErrWrongNumberOfTypeArgs{aexpr, g}}
+	}
+	candidates := g.candidates(targs)
+	if len(candidates) == 0 {
+		return aexpr, []error{ErrNoInstance{aexpr}}
+	}
+	aexpr.fun = candidates[0].fun
+	aexpr.knownType = knownType{aexpr.fun.Type()}
+	return aexpr, nil
+}
+
+// Check the instantiation T[T1, T2] of a generic type. Returns false if
+// index.X does not name a generic type.
+func checkGenericType(index *ast.IndexListExpr, env Env) (*IndexListExpr, reflect.Type, bool, []error) {
+	if g, _ := lookupGeneric(index.X, env); g == nil || !g.isType {
+		return nil, nil, false, nil
+	}
+	aexpr, g, targs, errs := checkInstantiation(index, env)
+	if errs != nil {
+		return aexpr, nil, true, errs
+	} else if len(targs) != g.numTypeParams() {
+		return aexpr, nil, true, []error{ErrWrongNumberOfTypeArgs{aexpr, g}}
+	}
+	candidates := g.candidates(targs)
+	if len(candidates) == 0 {
+		return aexpr, nil, true, []error{ErrNoInstance{aexpr}}
+	}
+	t := candidates[0].typ
+	aexpr.knownType = knownType{t}
+	return aexpr, t, true, nil
+}
+
+// If the Fun of call names a generic func, select the instantiation with
+// the given type arguments. Omitted type arguments are inferred from the
+// call arguments, which must already be checked, provided they match
+// exactly one registered instantiation. Returns false if the Fun of call
+// is not a generic func.
+func checkGenericCallFun(call *CallExpr, env Env) (*IndexListExpr, []error, bool) {
+	var index *ast.IndexListExpr
+	switch fun := skipParens(call.CallExpr.Fun).(type) {
+	case *ast.IndexListExpr:
+		index = fun
+	case *ast.IndexExpr:
+		index = indexListExpr(fun)
+	default:
+		index = &ast.IndexListExpr{X: fun}
+	}
+	if g, _ := lookupGeneric(index.X, env); g == nil || g.isType {
+		return nil, nil, false
+	}
+	aexpr, g, targs, errs := checkInstantiation(index, env)
+	if errs != nil {
+		return aexpr, errs, true
+	}
+
+	candidates := g.candidates(targs)
+	if len(targs) == g.numTypeParams() {
+		if len(candidates) == 0 {
+			return aexpr, []error{ErrNoInstance{aexpr}}, true
+		}
+	} else {
+		matches := matchingInstances(call, candidates, false)
+		if len(matches) > 1 {
+			// As untyped constants may match several instantiations, prefer
+			// those accepting the default types of constant arguments
+			if defaults := matchingInstances(call, matches, true); len(defaults) == 1 {
+				matches = defaults
+			}
+		}
+		if len(matches) == 0 {
+			return aexpr, []error{ErrCannotInferTypeArgs{aexpr}}, true
+		} else if len(matches) > 1 {
+			return aexpr, []error{ErrAmbiguousTypeArgs{aexpr}}, true
+		}
+		candidates = matches
+	}
+	aexpr.fun = candidates[0].fun
+	aexpr.knownType = knownType{aexpr.fun.Type()}
+	return aexpr, nil, true
+}
+
+// Check the generic and the type arguments of index.
+func checkInstantiation(index *ast.IndexListExpr, env Env) (*IndexListExpr, *Generic, []reflect.Type, []error) {
+	aexpr := &IndexListExpr{IndexListExpr: index}
+	g, x := lookupGeneric(index.X, env)
+	if g == nil {
+		x, errs := CheckExpr(index.X, env)
+		aexpr.X = x
+		if errs == nil {
+			errs = []error{ErrNotGeneric{x}}
+		}
+		return aexpr, nil, nil, errs
+	}
+	aexpr.X = x
+
+	var errs []error
+	targs := make([]reflect.Type, len(index.Indices))
+	if index.Indices != nil {
+		aexpr.Indices = make([]Expr, len(index.Indices))
+	}
+	for i, targ := range index.Indices {
+		typ, t, isType, moreErrs := checkType(targ, env)
+		if !isType {
+			typ, moreErrs = CheckExpr(targ, env)
+			if moreErrs == nil {
+				moreErrs = []error{ErrNonTypeArg{typ}}
+			}
+		}
+		aexpr.Indices[i] = typ
+		errs = append(errs, moreErrs...)
+		if t != nil {
+			// Host instantiations cannot be of interpreted types
+			targs[i] = unhackType(t)
+		}
+	}
+	if errs != nil {
+		return aexpr, g, nil, errs
+	} else if len(targs) > g.numTypeParams() {
+		return aexpr, g, nil, []error{ErrWrongNumberOfTypeArgs{aexpr, g}}
+	}
+	return aexpr, g, targs, nil
+}
+
+// Returns the instantiations which accept the arguments of call.
+func matchingInstances(call *CallExpr, candidates []genericInstance, constDefaults bool) []genericInstance {
+	var matches []genericInstance
+	for _, candidate := range candidates {
+		if argsAssignableTo(call, candidate.fun.Type(), constDefaults) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// Reports whether the checked arguments of call may be passed to a func of
+// type ft. If constDefaults is set, untyped constant arguments are treated
+// as values of their default type.
+func argsAssignableTo(call *CallExpr, ft reflect.Type, constDefaults bool) bool {
+	numIn := ft.NumIn()
+	variadic := ft.IsVariadic() && call.CallExpr.Ellipsis == token.NoPos
+	in := func(i int) reflect.Type {
+		if variadic && i >= numIn - 1 {
+			return ft.In(numIn - 1).Elem()
+		}
+		return ft.In(i)
+	}
+
+	// f(g()), where g returns multiple values
+	if len(call.Args) == 1 && len(call.Args[0].KnownType()) > 1 {
+		argTs := call.Args[0].KnownType()
+		if len(argTs) < numIn - 1 || !variadic && len(argTs) != numIn {
+			return false
+		}
+		for i, argT := range argTs {
+			if !typeAssignableTo(argT, in(i)) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if len(call.Args) < numIn - 1 || !variadic && len(call.Args) != numIn {
+		return false
+	}
+	for i, arg := range call.Args {
+		if len(arg.KnownType()) != 1 {
+			return false
+		} else if ct, ok := arg.KnownType()[0].(ConstType); ok && constDefaults && ct != ConstNil {
+			if !typeAssignableTo(unhackType(ct.DefaultPromotion()), in(i)) {
+				return false
+			}
+		} else if ok, convErrs := exprAssignableTo(arg, in(i)); !ok || convErrs != nil {
+			// Constants which overflow or are truncated do not match
+			return false
+		}
+	}
+	return true
+}
+
+func indexListExpr(index *ast.IndexExpr) *ast.IndexListExpr {
+	return &ast.IndexListExpr{
+		X: index.X,
+		Lbrack: index.Lbrack,
+		Indices: []ast.Expr{index.Index},
+		Rbrack: index.Rbrack,
+	}
+}
+
+func skipParens(expr ast.Expr) ast.Expr {
+	for parens, ok := expr.(*ast.ParenExpr); ok; parens, ok = expr.(*ast.ParenExpr) {
+		expr = parens.X
+	}
+	return expr
+}
@@ -30,6 +30,11 @@ func checkSelectorExpr(selector *ast.SelectorExpr, env Env) (*SelectorExpr, []er
 		}
 	}
 
+	// Method expressions T.Method and (*T).Method
+	if typ, t, isType, _ := checkType(selector.X, env); isType && t != nil {
+		return checkMethodExpr(aexpr, typ, t)
+	}
+
 	x, errs := CheckExpr(selector.X, env)
 	aexpr.X = x
 	aexpr.Sel = &Ident{Ident: selector.Sel}
@@ -67,6 +72,17 @@ func checkSelectorExpr(selector *ast.SelectorExpr, env Env) (*SelectorExpr, []er
 		}
 	}
 
+	// Methods declared by interpreted code. The method set of T is not
+	// checked, as pointer methods may be called on addressable values.
+	if named, ok := t.(*Named); ok {
+		if m := named.lookupMethod(name); m != nil {
+			aexpr.namedMethod = m
+			aexpr.knownType = knownType{m.signature()}
+			return aexpr, errs
+		}
+		t = unhackType(t)
+	}
+
 	// Type.Method() on a non interface type returns a receiver type
 	// accepting X.KnownType as it's first argument. Value.Method() Binds the
 	// method to the value, and hence does not have the first argument.
@@ -93,3 +109,57 @@ func checkSelectorExpr(selector *ast.SelectorExpr, env Env) (*SelectorExpr, []er
 
 	return aexpr, append(errs, ErrUndefinedFieldOrMethod{aexpr})
 }
+
+// Check a method expression, whose operand typ is the type t. The method
+// must be in the method set of t, and the resulting func takes the receiver
+// as its first argument.
+func checkMethodExpr(aexpr *SelectorExpr, typ Expr, t reflect.Type) (*SelectorExpr, []error) {
+	aexpr.X = typ
+	aexpr.Sel = &Ident{Ident: aexpr.SelectorExpr.Sel}
+	aexpr.isMethodExpr = true
+	name := aexpr.Sel.Name
+
+	if named, ok := t.(*Named); ok {
+		m := named.lookupMethod(name)
+		if m == nil {
+			return aexpr, []error{ErrUndefinedFieldOrMethod{aexpr}}
+		} else if _, ok := named.MethodByName(name); !ok {
+			return aexpr, []error{ErrMethodExprPtrReceiver{aexpr}}
+		}
+		aexpr.namedMethod = m
+		aexpr.knownType = knownType{methodExprType(t, m.signature())}
+		return aexpr, nil
+	}
+
+	method, ok := t.MethodByName(name)
+	if !ok {
+		if k := t.Kind(); k != reflect.Ptr && k != reflect.Interface {
+			if _, ok := reflect.PtrTo(unhackType(t)).MethodByName(name); ok {
+				return aexpr, []error{ErrMethodExprPtrReceiver{aexpr}}
+			}
+		}
+		return aexpr, []error{ErrUndefinedFieldOrMethod{aexpr}}
+	}
+	aexpr.method = method.Index
+	if t.Kind() == reflect.Interface {
+		// Methods of interface types exclude the receiver
+		aexpr.knownType = knownType{methodExprType(t, method.Type)}
+	} else {
+		aexpr.knownType = knownType{method.Type}
+	}
+	return aexpr, nil
+}
+
+// Returns the type of a method expression with receiver type recvT, where
+// sig is the type of the method excluding the receiver.
+func methodExprType(recvT, sig reflect.Type) reflect.Type {
+	in := []reflect.Type{unhackType(recvT)}
+	for i := 0; i < sig.NumIn(); i += 1 {
+		in = append(in, sig.In(i))
+	}
+	out := make([]reflect.Type, sig.NumOut())
+	for i := range out {
+		out[i] = sig.Out(i)
+	}
+	return reflect.FuncOf(in, out, sig.IsVariadic())
+}
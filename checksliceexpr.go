@@ -36,6 +36,8 @@ func checkSliceExpr(slice *ast.SliceExpr, env Env) (*SliceExpr, []error) {
 		if t == ConstString {
 			// spec: ConstString[:] fields string
 			aexpr.knownType = knownType{stringType}
+		} else if t.Kind() == reflect.Array {
+			aexpr.knownType = knownType{reflect.SliceOf(t.Elem())}
 		} else {
 			aexpr.knownType = knownType(x.KnownType())
 		}
@@ -70,6 +72,9 @@ func checkSliceExpr(slice *ast.SliceExpr, env Env) (*SliceExpr, []error) {
 
 func checkSliceVectorExpr(x Expr, index ast.Expr, env Env) (Expr, int, []error) {
 	t := x.KnownType()[0]
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
 	i, iint, ok, errs := checkInteger(index, env)
 	if errs != nil && !i.IsConst() {
 		// Type check of index failed
@@ -85,7 +90,8 @@ func checkSliceVectorExpr(x Expr, index ast.Expr, env Env) (Expr, int, []error)
 		if iint < 0 {
 			errs = append(errs, ErrIndexOutOfBounds{i, x, iint})
 		} else if t.Kind() == reflect.Array {
-			if iint >= t.Len() {
+			// a[len(a):] is a valid slice
+			if iint > t.Len() {
 				errs = append(errs, ErrIndexOutOfBounds{i, x, iint})
 			}
 		}
@@ -10,6 +10,7 @@ import (
 
 type checkCtx struct {
 	outerFunc reflect.Type
+	inFunc bool
 	emptyReturnOk bool
 	stack []Stmt
 }
@@ -17,7 +18,8 @@ type checkCtx struct {
 // Place holder for something more substantial
 func CheckStmt(stmt ast.Stmt, env Env) (Stmt, []error) {
 	// Create a dummy env where variables can be added without affecting the global env
-	return checkStmt(stmt, env.PushScope(), checkCtx{})
+	astmt, errs := checkStmt(stmt, env.PushScope(), checkCtx{})
+	return astmt, append(errs, checkBranches(astmt)...)
 }
 
 func checkBlock(block *ast.BlockStmt, env Env, ctx checkCtx) (*BlockStmt, []error) {
@@ -175,6 +177,9 @@ func checkStmt(stmt ast.Stmt, env Env, ctx checkCtx) (Stmt, []error) {
 				if assignable {
 					errs = append(errs, convErrs...)
 				}
+				if lhs, ok := skipSuperfluousParens(a.Lhs[i]).(*Ident); ok && assignable && !isMulti {
+					assignable = namedExprAssignableTo(a.Rhs[i], lhs.KnownType()[0])
+				}
 			}
 			if !assignable {
 				if isMulti {
@@ -187,7 +192,7 @@ func checkStmt(stmt ast.Stmt, env Env, ctx checkCtx) (Stmt, []error) {
 
 		for i, name := range names {
 			if name != "_" {
-				env.AddVar(name, reflect.New(types[i]))
+				env.AddVar(name, newVar(types[i]))
 			}
 		}
 done:
@@ -205,6 +210,9 @@ done:
 	case *ast.BlockStmt:
 		return checkBlock(s, env, ctx)
 
+	case *ast.DeclStmt:
+		return checkDeclStmt(s, env)
+
 	case *ast.EmptyStmt:
 		return &EmptyStmt{EmptyStmt: s}, nil
 
@@ -212,6 +220,12 @@ done:
 		x, errs := CheckExpr(s.X, env)
 		return &ExprStmt{ExprStmt: s, X: x}, errs
 
+	case *ast.DeferStmt:
+		return checkDeferStmt(s, env, ctx)
+
+	case *ast.GoStmt:
+		return checkGoStmt(s, env)
+
 	case *ast.IfStmt:
 		astmt := &IfStmt{IfStmt: s}
 		env = env.PushScope() // Env for the if block
@@ -255,9 +269,18 @@ done:
 		astmt := &LabeledStmt{LabeledStmt: s}
 		astmt.Label = &Ident{Ident: s.Label}
 		astmt.Stmt, moreErrs = checkStmt(s.Stmt, env, ctx)
+		errs = append(errs, moreErrs...)
 		switch loop := astmt.Stmt.(type) {
 		case *ForStmt:
 			loop.label = astmt.Label.Name
+		case *RangeStmt:
+			loop.label = astmt.Label.Name
+		case *SelectStmt:
+			loop.label = astmt.Label.Name
+		case *SwitchStmt:
+			loop.label = astmt.Label.Name
+		case *TypeSwitchStmt:
+			loop.label = astmt.Label.Name
 		}
 		return astmt, errs
 
@@ -278,6 +301,9 @@ done:
 		errs = append(errs, moreErrs...)
 		return astmt, errs
 
+	case *ast.RangeStmt:
+		return checkRangeStmt(s, env, ctx)
+
 	case *ast.ReturnStmt:
 		astmt := &ReturnStmt{ReturnStmt: s}
 		if s.Results != nil {
@@ -288,11 +314,13 @@ done:
 		if ctx.outerFunc != nil {
 			numOut = ctx.outerFunc.NumOut()
 		}
+		resultErrs := make([][]error, len(s.Results))
+		for i, result := range s.Results {
+			astmt.Results[i], resultErrs[i] = CheckExpr(result, env)
+		}
 		if len(s.Results) == 1 {
-			r, _ := CheckExpr(s.Results[0], env)
-			if kt := r.KnownType(); len(kt) > 1 {
+			if kt := astmt.Results[0].KnownType(); len(kt) > 1 {
 				numResults = len(kt)
-				astmt.Results[0] = r
 				for i = 0; i < numOut && i < numResults ; i += 1 {
 					t := ctx.outerFunc.Out(i)
 					if !typeAssignableTo(kt[i], ctx.outerFunc.Out(1)) {
@@ -305,7 +333,7 @@ done:
 		numResults = len(s.Results)
 		for i = 0; i < numResults && i < numOut; i += 1 {
 			t := ctx.outerFunc.Out(i)
-			astmt.Results[i], ok, moreErrs = checkExprAssignableTo(s.Results[i], t, env)
+			ok, moreErrs = checkedExprAssignableTo(astmt.Results[i], resultErrs[i], t)
 			if ok {
 				errs = append(errs, moreErrs...)
 			} else {
@@ -313,8 +341,7 @@ done:
 			}
 		}
 		for ; i < numResults; i += 1 {
-			astmt.Results[i], moreErrs = CheckExpr(s.Results[i], env)
-			errs = append(errs, moreErrs...)
+			errs = append(errs, resultErrs[i]...)
 		}
 checkcount:
 		if ctx.outerFunc != nil && numResults != numOut && !(numOut == 0 && ctx.emptyReturnOk) {
@@ -322,6 +349,12 @@ checkcount:
 		}
 		return astmt, errs
 
+	case *ast.SelectStmt:
+		return checkSelectStmt(s, env, ctx)
+
+	case *ast.SendStmt:
+		return checkSendStmt(s, env)
+
 	case *ast.SwitchStmt:
 		body := &BlockStmt{BlockStmt: s.Body, List: make([]Stmt, len(s.Body.List))}
 		astmt := &SwitchStmt{SwitchStmt: s, Body: body}
@@ -374,7 +407,8 @@ checkcount:
 					errs = append(errs, moreErrs...)
 				}
 			}
-			astmt.Body.List[i], moreErrs = checkCaseClauseBody(aclause, env, ctx)
+			astmt.Body.List[i], moreErrs = checkCaseClauseBody(aclause, env.PushScope(), ctx)
+			errs = append(errs, moreErrs...)
 		}
 		return astmt, errs
 
@@ -409,7 +443,7 @@ checkcount:
 			tag = assign.Rhs[0]
 
 			if moreErrs == nil || tag.IsConst() {
-				t = caseEnv.Var(name).Elem().Type()
+				t = varType(caseEnv.Var(name))
 			}
 		} else if exprstmt, ok := astmt.Assign.(*ExprStmt); ok {
 			tag = exprstmt.X
@@ -429,8 +463,10 @@ checkcount:
 			t = nil
 		}
 
+		seen := map[reflect.Type]bool{}
 		for i, stmt := range s.Body.List {
-			caseEnv := env.PushScope()
+			// In default and multi type clauses the variable has the tag's type
+			caseEnv := caseEnv.PushScope()
 			clause := stmt.(*ast.CaseClause)
 			aclause := &CaseClause{CaseClause: clause}
 			if clause.List == nil {
@@ -448,11 +484,14 @@ checkcount:
 						errs = append(errs, ErrBuiltinNonTypeArg{aexpr})
 					}
 				// isType == true && tt == nil for unimplemented types
+				} else if tt != nil && seen[tt] {
+					errs = append(errs, ErrDuplicateTypeCase{aexpr})
 				} else if t != nil && tt != nil {
-					if tt.Kind() != reflect.Interface && !tt.Implements(t) {
+					seen[tt] = true
+					if tt.Kind() != reflect.Interface && !implements(tt, t) {
 						errs = append(errs, ErrImpossibleTypeCase{aexpr, tag})
 					} else if len(clause.List) == 1 {
-						caseEnv.AddVar(name, reflect.New(tt))
+						caseEnv.AddVar(name, newVar(tt))
 					}
 				}
 				aclause.List[j] = aexpr
@@ -467,6 +506,202 @@ checkcount:
 	}
 }
 
+func checkDeferStmt(s *ast.DeferStmt, env Env, ctx checkCtx) (*DeferStmt, []error) {
+	astmt := &DeferStmt{DeferStmt: s}
+	call, errs := checkCallExpr(s.Call, env)
+	astmt.Call = call
+	if !ctx.inFunc {
+		errs = append(errs, ErrDeferOutsideFunc{astmt})
+	}
+	if errs != nil {
+		return astmt, errs
+	} else if call.isTypeConversion {
+		return astmt, []error{ErrDeferNonCall{call}}
+	} else if call.isBuiltin && !builtinAllowedInStmt(call.Fun.(*Ident).Name) {
+		return astmt, []error{ErrDeferDiscardsResult{call}}
+	}
+	return astmt, nil
+}
+
+func checkGoStmt(s *ast.GoStmt, env Env) (*GoStmt, []error) {
+	astmt := &GoStmt{GoStmt: s}
+	call, errs := checkCallExpr(s.Call, env)
+	astmt.Call = call
+	if errs != nil {
+		return astmt, errs
+	} else if call.isTypeConversion {
+		return astmt, []error{ErrGoNonCall{call}}
+	} else if call.isBuiltin && !builtinAllowedInStmt(call.Fun.(*Ident).Name) {
+		return astmt, []error{ErrGoDiscardsResult{call}}
+	}
+	return astmt, nil
+}
+
+func checkRangeStmt(s *ast.RangeStmt, env Env, ctx checkCtx) (*RangeStmt, []error) {
+	astmt := &RangeStmt{RangeStmt: s}
+	env = env.PushScope() // Env for the range block
+
+	x, errs := CheckExpr(s.X, env)
+	astmt.X = x
+	if errs == nil || x.IsConst() {
+		if t, err := expectSingleType(x); err != nil {
+			errs = append(errs, err)
+		} else if t == ConstNil {
+			errs = append(errs, ErrUntypedNil{x})
+		} else {
+			if ct, ok := t.(ConstType); ok {
+				t = ct.DefaultPromotion()
+			}
+			astmt.xT = t
+			astmt.keyT, astmt.valueT = rangeTypes(t)
+			if astmt.keyT == nil {
+				errs = append(errs, ErrCannotRangeOver{x})
+			} else if astmt.valueT == nil && s.Value != nil {
+				errs = append(errs, ErrTooManyRangeVars{x})
+			}
+		}
+	}
+
+	types := []reflect.Type{astmt.keyT, astmt.valueT}
+	vars := []*Expr{&astmt.Key, &astmt.Value}
+	for i, l := range []ast.Expr{s.Key, s.Value} {
+		if l == nil {
+			continue
+		} else if s.Tok == token.DEFINE {
+			ident, ok := l.(*ast.Ident)
+			*vars[i] = fakeCheckExpr(l, env)
+			if !ok {
+				errs = append(errs, ErrNonNameInDeclaration{*vars[i]})
+			} else if ident.Name != "_" && types[i] != nil {
+				env.AddVar(ident.Name, newVar(types[i]))
+			}
+		} else if isBlankIdentifier(l) {
+			*vars[i] = fakeCheckExpr(l, env)
+		} else {
+			lhs, moreErrs := CheckExpr(l, env)
+			*vars[i] = lhs
+			if moreErrs != nil && !lhs.IsConst() {
+				errs = append(errs, moreErrs...)
+				continue
+			}
+			lt, err := expectSingleType(lhs)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			ll := skipSuperfluousParens(lhs)
+			if index, ok := ll.(*IndexExpr); ok && index.X.KnownType()[0].Kind() == reflect.Map {
+				// map index expressions are assignable
+			} else if !isAddressable(ll) {
+				errs = append(errs, ErrCannotAssignToUnaddressable{lhs})
+				continue
+			}
+			if types[i] != nil && !typeAssignableTo(types[i], lt) {
+				errs = append(errs, ErrBadRangeAssign{lhs, types[i]})
+			}
+		}
+	}
+
+	body, moreErrs := checkBlock(s.Body, env, ctx)
+	astmt.Body = body
+	errs = append(errs, moreErrs...)
+	return astmt, errs
+}
+
+// Returns the key and value types produced by ranging over t. The
+// value type is nil for types which produce a single iteration
+// variable, and both are nil if t cannot be ranged over.
+func rangeTypes(t reflect.Type) (key, value reflect.Type) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		if t.Elem().Kind() == reflect.Array {
+			return intType, t.Elem().Elem()
+		}
+	case reflect.Array, reflect.Slice:
+		return intType, t.Elem()
+	case reflect.String:
+		return intType, RuneType
+	case reflect.Map:
+		return t.Key(), t.Elem()
+	case reflect.Chan:
+		if t.ChanDir()&reflect.RecvDir != 0 {
+			return t.Elem(), nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return t, nil
+	}
+	return nil, nil
+}
+
+func checkSelectStmt(s *ast.SelectStmt, env Env, ctx checkCtx) (*SelectStmt, []error) {
+	var errs, moreErrs []error
+	body := &BlockStmt{BlockStmt: s.Body, List: make([]Stmt, len(s.Body.List))}
+	astmt := &SelectStmt{SelectStmt: s, Body: body}
+	for i, stmt := range s.Body.List {
+		clause := stmt.(*ast.CommClause)
+		aclause := &CommClause{CommClause: clause}
+		clauseEnv := env.PushScope()
+		switch comm := clause.Comm.(type) {
+		case nil:
+		case *ast.SendStmt:
+			aclause.Comm, moreErrs = checkSendStmt(comm, clauseEnv)
+			errs = append(errs, moreErrs...)
+		default:
+			aclause.Comm, moreErrs = checkStmt(comm, clauseEnv, ctx)
+			errs = append(errs, moreErrs...)
+			var recv Expr
+			switch c := aclause.Comm.(type) {
+			case *ExprStmt:
+				recv = c.X
+			case *AssignStmt:
+				if len(c.Rhs) == 1 {
+					recv = c.Rhs[0]
+				}
+			}
+			if unary, ok := skipSuperfluousParens(recv).(*UnaryExpr); ok && unary.Op == token.ARROW {
+				aclause.recv = unary
+			} else {
+				errs = append(errs, ErrBadSelectCase{aclause})
+			}
+		}
+		if clause.Body != nil {
+			aclause.Body = make([]Stmt, len(clause.Body))
+		}
+		for j, stmt := range clause.Body {
+			aclause.Body[j], moreErrs = checkStmt(stmt, clauseEnv, ctx)
+			errs = append(errs, moreErrs...)
+		}
+		astmt.Body.List[i] = aclause
+	}
+	return astmt, errs
+}
+
+func checkSendStmt(s *ast.SendStmt, env Env) (*SendStmt, []error) {
+	astmt := &SendStmt{SendStmt: s}
+	ch, errs := CheckExpr(s.Chan, env)
+	astmt.Chan = ch
+	if errs != nil && !ch.IsConst() {
+		astmt.Value = fakeCheckExpr(s.Value, env)
+		return astmt, errs
+	}
+	t, err := expectSingleType(ch)
+	if err != nil {
+		astmt.Value = fakeCheckExpr(s.Value, env)
+		return astmt, append(errs, err)
+	} else if t.Kind() != reflect.Chan || t.ChanDir() & reflect.SendDir == 0 {
+		astmt.Value = fakeCheckExpr(s.Value, env)
+		return astmt, append(errs, ErrInvalidSendTo{astmt})
+	}
+	value, ok, moreErrs := checkExprAssignableTo(s.Value, t.Elem(), env)
+	astmt.Value = value
+	errs = append(errs, moreErrs...)
+	if !ok {
+		errs = append(errs, ErrBadSendValue{value, t.Elem()})
+	}
+	return astmt, errs
+}
+
 func checkCond(cond ast.Expr, parent Stmt, env Env, ctx checkCtx) (Expr, []error) {
 	if cond == nil {
 		return nil, nil
@@ -562,13 +797,179 @@ func findLabel(branch *BranchStmt, ctx checkCtx) (jump []Stmt) {
 			}
 		case *ForStmt:
 			open = append(open, s.Body)
+		case *RangeStmt:
+			open = append(open, s.Body)
 		case *SwitchStmt:
 			open = append(open, s.Body)
 		case *TypeSwitchStmt:
 			open = append(open, s.Body)
+		case *SelectStmt:
+			open = append(open, s.Body)
+		case *CommClause:
+			open = append(open, s.Body...)
 		}
 		s = len(open) - 1
 	}
 	return nil
 }
 
+
+// Check the branch statements and labels of a function body or top level
+// statement. Labels are scoped to the function body, so function literals
+// are checked separately when they are checked.
+func checkBranches(stmt Stmt) []error {
+	var errs []error
+	defined := map[string]*LabeledStmt{}
+	used := map[string]bool{}
+	var labels []*LabeledStmt
+
+	var walk func(stmt Stmt, stack []Stmt)
+	walk = func(stmt Stmt, stack []Stmt) {
+		if b, ok := stmt.(*BlockStmt); stmt == nil || ok && b == nil {
+			return
+		}
+		stack = append(stack, stmt)
+		switch s := stmt.(type) {
+		case *BlockStmt:
+			for _, x := range s.List {
+				walk(x, stack)
+			}
+		case *CaseClause:
+			for _, x := range s.Body {
+				walk(x, stack)
+			}
+		case *CommClause:
+			for _, x := range s.Body {
+				walk(x, stack)
+			}
+		case *IfStmt:
+			walk(s.Body, stack)
+			walk(s.Else, stack)
+		case *LabeledStmt:
+			if _, ok := defined[s.Label.Name]; ok {
+				errs = append(errs, ErrLabelRedefined{s})
+			} else if s.Label.Name != "_" {
+				defined[s.Label.Name] = s
+				labels = append(labels, s)
+			}
+			walk(s.Stmt, stack)
+		case *ForStmt:
+			walk(s.Body, stack)
+		case *RangeStmt:
+			walk(s.Body, stack)
+		case *SwitchStmt:
+			walk(s.Body, stack)
+		case *TypeSwitchStmt:
+			walk(s.Body, stack)
+		case *SelectStmt:
+			walk(s.Body, stack)
+		case *BranchStmt:
+			if err := checkBranch(s, stack, used); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	walk(stmt, nil)
+
+	for _, l := range labels {
+		if !used[l.Label.Name] {
+			errs = append(errs, ErrLabelNotUsed{l})
+		}
+	}
+	return errs
+}
+
+// Check a single branch statement, where stack is the trace of statements
+// from the root of the function body to the branch.
+func checkBranch(branch *BranchStmt, stack []Stmt, used map[string]bool) error {
+	if branch.Tok == token.FALLTHROUGH {
+		return checkFallthrough(branch, stack)
+	} else if branch.Label == nil {
+		for i := len(stack) - 1; i >= 0; i -= 1 {
+			switch stack[i].(type) {
+			case *ForStmt, *RangeStmt:
+				return nil
+			case *SwitchStmt, *TypeSwitchStmt, *SelectStmt:
+				if branch.Tok == token.BREAK {
+					return nil
+				}
+			}
+		}
+		return ErrBranchOutsideLoop{branch}
+	}
+
+	jump := findLabel(branch, checkCtx{stack: stack})
+	if jump == nil {
+		if branch.Tok == token.GOTO {
+			return ErrLabelNotDefined{branch}
+		}
+		return ErrInvalidBranchLabel{branch}
+	}
+	target := jump[len(jump)-1].(*LabeledStmt)
+	used[target.Label.Name] = true
+
+	switch branch.Tok {
+	case token.BREAK:
+		switch target.Stmt.(type) {
+		case *ForStmt, *RangeStmt, *SwitchStmt, *TypeSwitchStmt, *SelectStmt:
+			return nil
+		}
+		return ErrInvalidBranchLabel{branch}
+	case token.CONTINUE:
+		switch target.Stmt.(type) {
+		case *ForStmt, *RangeStmt:
+			return nil
+		}
+		return ErrInvalidBranchLabel{branch}
+	}
+
+	// The jump contains the blocks entered to reach the label, and the
+	// statements of the label's block between the goto and the label.
+	for _, s := range jump[:len(jump)-1] {
+		if s.Pos() <= target.Pos() && target.End() <= s.End() {
+			return ErrGotoIntoBlock{branch}
+		}
+	}
+	if target.Pos() > branch.Pos() {
+		for _, s := range jump[:len(jump)-1] {
+			if isVarDecl(s) {
+				return ErrGotoOverDecl{branch}
+			}
+		}
+	}
+	return nil
+}
+
+// A fallthrough must be the last statement of a non-final clause of an
+// expression switch.
+func checkFallthrough(branch *BranchStmt, stack []Stmt) error {
+	n := len(stack)
+	if n < 4 {
+		return ErrMisplacedFallthrough{branch}
+	}
+	clause, ok := stack[n-2].(*CaseClause)
+	if !ok || clause.Body[len(clause.Body)-1] != branch {
+		return ErrMisplacedFallthrough{branch}
+	}
+	body := stack[n-3].(*BlockStmt)
+	if _, ok := stack[n-4].(*TypeSwitchStmt); ok {
+		return ErrFallthroughTypeSwitch{branch}
+	} else if body.List[len(body.List)-1] == clause {
+		return ErrFallthroughFinalCase{branch}
+	}
+	return nil
+}
+
+// Determine if stmt declares variables in its enclosing block.
+func isVarDecl(stmt Stmt) bool {
+	switch s := stmt.(type) {
+	case *AssignStmt:
+		return s.Tok == token.DEFINE
+	case *DeclStmt:
+		gen, ok := s.Decl.(*ast.GenDecl)
+		return ok && gen.Tok == token.VAR
+	case *LabeledStmt:
+		return isVarDecl(s.Stmt)
+	}
+	return false
+}
@@ -1,9 +1,11 @@
 package eval
 
 import (
+	"reflect"
+	"testing"
+
 	"go/ast"
 	"go/token"
-	"testing"
 )
 
 func TestGotoBackward(t *testing.T) {
@@ -156,6 +158,8 @@ func findGoto(stmt Stmt, stack []Stmt) []Stmt {
 		return findGoto(s.Stmt, stack)
 	case *ForStmt:
 		return findGoto(s.Body, stack)
+	case *RangeStmt:
+		return findGoto(s.Body, stack)
 	case *SwitchStmt:
 		return findGoto(s.Body, stack)
 	case *TypeSwitchStmt:
@@ -176,3 +180,192 @@ func gothere(label string) *BranchStmt {
 		},
 	}
 }
+
+func TestCheckRangeErrors(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Vars["c"] = reflect.ValueOf(new(chan int))
+	env.Vars["s"] = reflect.ValueOf(new(string))
+	expectCheckError(t, "for range 1.5 {}", env, "cannot range over 1.5 (type untyped number)")
+	expectCheckError(t, "for x, y := range c {}", env, "too many variables in range over c")
+	expectCheckError(t, "for s = range []int{} {}", env, "cannot assign type int to s (type string) in range")
+}
+
+func TestCheckDeclErrors(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Vars["x"] = reflect.ValueOf(new(int))
+	expectCheckError(t, "const c = x", env, "const initializer x is not a constant")
+	expectCheckError(t, "const c int8 = 300", env, "constant 300 overflows int8")
+	expectCheckError(t, "const a, b = 1", env, "missing value in const declaration")
+	expectCheckError(t, "var a, b = 1", env, "assignment count mismatch: 2 = 1")
+	expectCheckError(t, `var s string = x`, env, "cannot use x (type int) as type string in assignment")
+	expectCheckError(t, "var n = nil", env, "use of untyped nil")
+	expectCheckError(t, "y := iota", env, "undefined: iota")
+}
+
+func TestCheckStructTypeErrors(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Types["T"] = reflect.TypeOf(struct{ A int }{})
+	expectCheckError(t, "type S struct{ a int; a string }", env, "duplicate field a")
+	expectCheckError(t, "type S struct{ T; T }", env, "duplicate field T")
+	env.Types["I"] = reflect.TypeOf(new(error)).Elem()
+	expectCheckError(t, "type S struct{ *I }", env, "embedded type cannot be a pointer to interface")
+	env.Types["P"] = reflect.TypeOf(new(struct{ A int }))
+	expectCheckError(t, "type S struct{ *P }", env, "embedded type cannot be a pointer")
+	expectCheckError(t, "var s struct{ int }", env,
+		"embedded field of unexported type int not supported by the interpreter")
+	expectCheckError(t, "var s struct{ error }", env,
+		"embedded field of unexported type error not supported by the interpreter")
+	expectCheckError(t, "struct{ T }{A: 1}", env,
+		"unknown struct { struct { A int } } field 'A' in struct literal")
+}
+
+func TestCheckNamedAssignErrors(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "type Celsius float64", env)
+	expectInterp(t, "type Fahr float64", env)
+	expectInterp(t, "type List []int", env)
+	expectInterp(t, "c := Celsius(3.5)", env)
+	expectInterp(t, "f := 1.5", env)
+	expectCheckError(t, "var x float64 = c", env, "cannot use c (type Celsius) as type float64 in assignment")
+	expectCheckError(t, "var g Fahr = c", env, "cannot use c (type Celsius) as type Fahr in assignment")
+	expectCheckError(t, "var d Celsius = f", env, "cannot use f (type float64) as type Celsius in assignment")
+	expectCheckError(t, "f = c", env, "cannot use c (type Celsius) as type float64 in assignment")
+	expectCheckError(t, "c = Fahr(1)", env, "cannot use Fahr(1) (type Fahr) as type Celsius in assignment")
+
+	// Identical types, and unnamed types with identical underlying types
+	expectInterp(t, "var d Celsius = c + 1", env)
+	expectInterp(t, "var l List = []int{1}", env)
+	expectInterp(t, "var s []int = l", env)
+	expectResult(t, "float64(d) + float64(len(s))", env, 5.5)
+}
+
+func TestCheckArrayTypeErrors(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Vars["n"] = reflect.ValueOf(new(int))
+	expectCheckError(t, "var a [n]int", env, "non-constant array bound n")
+	expectCheckError(t, "var a [-1]int", env, "array bound must be non-negative")
+	expectCheckError(t, `var a ["a"]int`, env, `invalid array bound "a"`)
+	expectCheckError(t, "var a [1 << 50]int64", env, "array bound is too large")
+	expectCheckError(t, "var a [...]int", env, "use of [...] array outside of array literal")
+	expectCheckError(t, "[2]int{1, 2, 3}", env, "array index 3 out of bounds [0:2]")
+	expectCheckError(t, "[1][]int{} == [1][]int{}", env,
+		"invalid operation: [1][]int literal == [1][]int literal ([1][]int cannot be compared)")
+}
+
+func TestCheckFuncTypeErrors(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectCheckError(t, "var f func(int) int = func(int) string { return \"\" }", env,
+		"cannot use func literal (type func(int) string) as type func(int) int in assignment")
+}
+
+func TestCheckInterfaceTypeErrors(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Types["L"] = reflect.TypeOf(typeAssertLen{})
+	env.Types["Lener"] = reflect.TypeOf(new(typeAssertLener)).Elem()
+	env.Vars["i"] = reflect.ValueOf(new(interface{ Len() int }))
+	expectCheckError(t, "type I interface{ Len() int; Len() string }", env, "duplicate method Len")
+	expectCheckError(t, "type I interface{ Lener; Len() int }", env, "duplicate method Len")
+	expectCheckError(t, "type I interface{ L }", env, "interface contains embedded non-interface L")
+	expectCheckError(t, "var s interface{ Len() int } = 1", env,
+		"cannot convert 1 to type interface { Len() int }",
+		"cannot use 1 (type int) as type interface { Len() int } in assignment")
+	expectCheckError(t, "interface{ Len() int }(1).(int)", env,
+		"cannot convert 1 to type interface { Len() int }",
+		"cannot convert 1 (type int) to type interface { Len() int }",
+		"impossible type assertion:\n\tint does not implement interface { Len() int } (missing Len method)")
+}
+
+func TestCheckGoStmtErrors(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Vars["s"] = reflect.ValueOf(new([]int))
+	expectCheckError(t, "go int(1)", env, "go requires function call, not conversion")
+	expectCheckError(t, "go len(s)", env, "go discards result of len(s)")
+	expectCheckError(t, "go append(s, 1)", env, "go discards result of append(s, 1)")
+}
+
+func TestCheckDeferStmtErrors(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Vars["s"] = reflect.ValueOf(new([]int))
+	expectCheckError(t, "defer recover()", env, "defer outside function")
+	expectCheckError(t, "func() { defer int(1) }", env, "defer requires function call, not conversion")
+	expectCheckError(t, "func() { defer len(s) }", env, "defer discards result of len(s)")
+	expectCheckError(t, "recover(1)", env, "too many arguments to recover: recover(1)")
+}
+
+func TestCheckSelectStmtErrors(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Vars["r"] = reflect.ValueOf(new(<-chan int))
+	env.Vars["s"] = reflect.ValueOf(new(chan<- int))
+	env.Vars["i"] = reflect.ValueOf(new(int))
+	expectCheckError(t, "select { case len(\"\"): }", env, "select case must be receive, send or assign recv")
+	expectCheckError(t, "select { case r <- 1: }", env, "invalid operation: r <- 1 (send to receive-only type <-chan int)")
+	expectCheckError(t, "select { case i <- 1: }", env, "invalid operation: i <- 1 (send to non-chan type int)")
+	expectCheckError(t, "select { case s <- \"a\": }", env,
+		"cannot convert \"a\" to type int",
+		"cannot use \"a\" (type string) as type int in send")
+	expectCheckError(t, "select { case <-s: }", env, "invalid operation: <-s (receive from send-only type chan<- int)")
+}
+
+func TestCheckSendStmtErrors(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Vars["r"] = reflect.ValueOf(new(<-chan int))
+	env.Vars["s"] = reflect.ValueOf(new(chan<- int))
+	env.Vars["i"] = reflect.ValueOf(new(int))
+	expectCheckError(t, "r <- 1", env, "invalid operation: r <- 1 (send to receive-only type <-chan int)")
+	expectCheckError(t, "i <- 1", env, "invalid operation: i <- 1 (send to non-chan type int)")
+	expectCheckError(t, "s <- nil", env,
+		"cannot convert nil to type int",
+		"cannot use nil as type int in send")
+	expectCheckError(t, "s <- 1.5", env, "constant 1.5 truncated to integer")
+	expectCheckError(t, "s <- \"a\"", env,
+		"cannot convert \"a\" to type int",
+		"cannot use \"a\" (type string) as type int in send")
+}
+
+func TestCheckErrorTypeErrors(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Vars["e"] = reflect.ValueOf(new(error))
+	expectCheckError(t, "var x comparable", env,
+		"cannot use type comparable outside a type constraint: interface is (or embeds) comparable")
+	expectCheckError(t, "var x error = 1", env,
+		"cannot convert 1 to type error",
+		"cannot use 1 (type int) as type error in assignment")
+	expectCheckError(t, "switch e.(type) { case int: }", env,
+		"impossible type switch case: e (error) cannot have dynamic type int (missing Error method)")
+	expectCheckError(t, "switch e.(type) { case error, any, error: }", env, "duplicate case error in type switch")
+}
+
+func TestCheckBranchErrors(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectCheckError(t, "goto L", env, "label L not defined")
+	expectCheckError(t, "{ goto L; { L: } }", env, "goto L jumps into block")
+	expectCheckError(t, "{ goto L; x := 1; _ = x; L: }", env, "goto L jumps over variable declaration")
+	expectCheckError(t, "{ goto L; var x int; _ = x; L: }", env, "goto L jumps over variable declaration")
+	expectCheckError(t, "switch { case true: goto L; default: L: }", env, "goto L jumps into block")
+	expectCheckError(t, "{ L: ; L: ; goto L }", env, "label L already defined")
+	expectCheckError(t, "L: for {}", env, "label L defined and not used")
+	expectCheckError(t, "break", env, "break is not in a loop, switch, or select")
+	expectCheckError(t, "switch { default: continue }", env, "continue is not in a loop")
+	expectCheckError(t, "L: { for { break L } }", env, "invalid break label L")
+	expectCheckError(t, "L: switch { default: for { continue L } }", env, "invalid continue label L")
+	expectCheckError(t, "for { break L }", env, "invalid break label L")
+	expectCheckError(t, "fallthrough", env, "fallthrough statement out of place")
+	expectCheckError(t, "switch { case true: fallthrough; x := 1; _ = x; default: }", env, "fallthrough statement out of place")
+	expectCheckError(t, "switch { case true: fallthrough }", env, "cannot fallthrough final case in switch")
+	expectCheckError(t, "switch interface{}(1).(type) { case int: fallthrough; default: }", env, "cannot fallthrough in type switch")
+	expectCheckError(t, "{ L: ; _ = func() { goto L } }", env, "label L not defined", "label L defined and not used")
+}
+
+func TestCheckMethodDeclErrors(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "type T struct{ X int }", env)
+	expectInterp(t, "type P *int", env)
+	expectInterp(t, "func (T) M() {}", env)
+	expectCheckError(t, "func (T) M() {}", env, "duplicate method M")
+	expectCheckError(t, "func (*T) X() {}", env, "type T has both field and method named X")
+	expectCheckError(t, "func (int) M() {}", env, "cannot define new methods on non-local type int")
+	expectCheckError(t, "func (P) M() {}", env, "invalid receiver type P")
+	expectCheckError(t, "func (t T) N() int { return t.Y }", env, "t.Y undefined (type T has no field or method Y)")
+	expectCheckError(t, "T.N", env, "T.N undefined (type T has no method N)")
+	expectCheckError(t, "T{}.N()", env, "T literal.N undefined (type T has no field or method N)")
+}
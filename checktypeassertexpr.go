@@ -25,7 +25,7 @@ func checkTypeAssertExpr(assert *ast.TypeAssertExpr, env Env) (*TypeAssertExpr,
 		errs = append(errs, moreErrs...)
 		if t != nil {
 			aexpr.knownType = knownType{t}
-			if t.Kind() != reflect.Interface && !unhackType(t).Implements(xT) {
+			if t.Kind() != reflect.Interface && !implements(t, xT) {
 				errs = append(errs, ErrImpossibleTypeAssert{aexpr})
 			}
 		}
@@ -29,13 +29,15 @@ func checkUnaryExpr(unary *ast.UnaryExpr, env Env) (*UnaryExpr, []error) {
 					aexpr.knownType = knownType{ptrT}
 				}
 			} else {
-				ptrT := reflect.PtrTo(unhackType(t))
+				ptrT := ptrTo(t)
 				aexpr.knownType = knownType{ptrT}
 			}
 			aexpr.X = x
 		} else if unary.Op == token.ARROW { // <-
-			if (t.Kind() != reflect.Chan) || (t.ChanDir() | reflect.RecvDir == 0) {
+			if (t.Kind() != reflect.Chan) || (t.ChanDir() & reflect.RecvDir == 0) {
 				errs = append(errs, ErrInvalidRecvFrom{x})
+			} else {
+				aexpr.knownType = knownType{t.Elem()}
 			}
 		} else {
 			aexpr.X = x
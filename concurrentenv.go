@@ -0,0 +1,183 @@
+package eval
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// A ConcurrentEnv is an Env whose lookups are lock-free, so that a shared
+// base may be used by many goroutines at once. The declarations of each
+// scope are held in an immutable table, which each Add replaces with a
+// copy. Adds to a scope are serialized with each other, but never block
+// lookups, which see either the old or the new table.
+//
+// Overlay returns a new layer on top of an Env, to which the declarations
+// of a single evaluation may be added without affecting the Env below or
+// any other overlay. Unlike a scope returned by PushScope, an overlay also
+// holds its own packages.
+type ConcurrentEnv struct {
+	parent *ConcurrentEnv
+
+	// Scopes created by PushScope hold no packages of their own
+	block bool
+
+	table atomic.Value // *envTable
+	mu sync.Mutex
+}
+
+// The immutable declarations of a ConcurrentEnv scope.
+type envTable struct {
+	vars map[string]reflect.Value
+	funcs map[string]reflect.Value
+	consts map[string]reflect.Value
+	types map[string]reflect.Type
+	pkgs map[string]Env
+}
+
+var emptyEnvTable = &envTable{}
+
+// Create a root ConcurrentEnv holding the declarations of the top scope of
+// env, including its packages. If env is nil, the ConcurrentEnv is empty.
+func MakeConcurrentEnv(env *SimpleEnv) *ConcurrentEnv {
+	cenv := &ConcurrentEnv{}
+	if env == nil {
+		cenv.table.Store(emptyEnvTable)
+		return cenv
+	}
+	env.mu.RLock()
+	defer env.mu.RUnlock()
+	table := &envTable{
+		vars: copyValueMap(env.Vars),
+		funcs: copyValueMap(env.Funcs),
+		consts: copyValueMap(env.Consts),
+		types: map[string]reflect.Type{},
+		pkgs: map[string]Env{},
+	}
+	for name, t := range env.Types {
+		table.types[name] = t
+	}
+	for name, p := range env.Pkgs {
+		table.pkgs[name] = p
+	}
+	cenv.table.Store(table)
+	return cenv
+}
+
+func (env *ConcurrentEnv) load() *envTable {
+	return env.table.Load().(*envTable)
+}
+
+func (env *ConcurrentEnv) Var(ident string) reflect.Value {
+	return env.load().vars[ident]
+}
+
+func (env *ConcurrentEnv) Func(ident string) reflect.Value {
+	return env.load().funcs[ident]
+}
+
+func (env *ConcurrentEnv) Const(ident string) reflect.Value {
+	return env.load().consts[ident]
+}
+
+func (env *ConcurrentEnv) Type(ident string) reflect.Type {
+	return env.load().types[ident]
+}
+
+// Returns the package pkg of the nearest overlay or root declaring it.
+func (env *ConcurrentEnv) Pkg(pkg string) Env {
+	for ; env != nil; env = env.parent {
+		if env.block {
+			continue
+		} else if p := env.load().pkgs[pkg]; p != nil {
+			return p
+		}
+	}
+	return nil
+}
+
+func (env *ConcurrentEnv) PushScope() Env {
+	return env.push(true)
+}
+
+func (env *ConcurrentEnv) PopScope() Env {
+	if env.parent == nil {
+		return nil
+	}
+	return env.parent
+}
+
+// Overlay returns a new scope above env, whose declarations and packages
+// are visible only through the overlay and scopes pushed onto it.
+func (env *ConcurrentEnv) Overlay() *ConcurrentEnv {
+	return env.push(false)
+}
+
+func (env *ConcurrentEnv) push(block bool) *ConcurrentEnv {
+	top := &ConcurrentEnv{parent: env, block: block}
+	top.table.Store(emptyEnvTable)
+	return top
+}
+
+// Replace the table of env with a copy modified by add.
+func (env *ConcurrentEnv) update(add func(table *envTable)) {
+	env.mu.Lock()
+	defer env.mu.Unlock()
+	table := *env.load()
+	add(&table)
+	env.table.Store(&table)
+}
+
+func (env *ConcurrentEnv) AddVar(ident string, v reflect.Value) {
+	env.update(func(table *envTable) {
+		table.vars = withValue(table.vars, ident, v)
+	})
+}
+
+func (env *ConcurrentEnv) AddFunc(ident string, f reflect.Value) {
+	env.update(func(table *envTable) {
+		table.funcs = withValue(table.funcs, ident, f)
+	})
+}
+
+func (env *ConcurrentEnv) AddConst(ident string, c reflect.Value) {
+	env.update(func(table *envTable) {
+		table.consts = withValue(table.consts, ident, c)
+	})
+}
+
+func (env *ConcurrentEnv) AddType(ident string, t reflect.Type) {
+	env.update(func(table *envTable) {
+		types := make(map[string]reflect.Type, len(table.types) + 1)
+		for name, u := range table.types {
+			types[name] = u
+		}
+		types[ident] = t
+		table.types = types
+	})
+}
+
+// Add pkg to the nearest overlay or root.
+func (env *ConcurrentEnv) AddPkg(pkg string, p Env) {
+	for env.block {
+		env = env.parent
+	}
+	env.update(func(table *envTable) {
+		pkgs := make(map[string]Env, len(table.pkgs) + 1)
+		for name, q := range table.pkgs {
+			pkgs[name] = q
+		}
+		pkgs[pkg] = p
+		table.pkgs = pkgs
+	})
+}
+
+// Returns a copy of m with ident set to v.
+func withValue(m map[string]reflect.Value, ident string, v reflect.Value) map[string]reflect.Value {
+	c := make(map[string]reflect.Value, len(m) + 1)
+	for name, u := range m {
+		c[name] = u
+	}
+	c[ident] = v
+	return c
+}
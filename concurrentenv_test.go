@@ -0,0 +1,93 @@
+package eval
+
+import (
+	"fmt"
+	"go/parser"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func makeConcurrentBase() *ConcurrentEnv {
+	env := MakeSimpleEnv()
+	limit := 10
+	env.Vars["limit"] = reflect.ValueOf(&limit)
+	env.Funcs["double"] = reflect.ValueOf(func(i int) int { return i * 2 })
+	env.Consts["Scale"] = reflect.ValueOf(3)
+	env.Types["Score"] = reflect.TypeOf(0)
+	env.Pkgs["example.com/log"] = MakeSimpleEnv()
+	return MakeConcurrentEnv(env)
+}
+
+func TestConcurrentEnv(t *testing.T) {
+	base := makeConcurrentBase()
+	overlay := base.Overlay()
+	expectInterp(t, `x := Score(double(limit) + Scale)`, overlay)
+	expectResult(t, `x`, overlay, 23)
+	expectCheckError(t, `x`, base, "undefined: x")
+
+	scope := overlay.PushScope()
+	expectInterp(t, `y := x + 1`, scope)
+	expectResult(t, `y`, scope, 24)
+	expectCheckError(t, `y`, overlay, "undefined: y")
+
+	// Packages are added to the overlay, never to the base
+	scope.AddPkg("log", base.Pkg("example.com/log"))
+	if overlay.Pkg("log") == nil || base.Pkg("log") != nil || scope.Pkg("example.com/log") == nil {
+		t.Fatalf("Wrong packages")
+	}
+	if base.PopScope() != nil || overlay.PopScope() != Env(base) {
+		t.Fatalf("Wrong scopes")
+	}
+}
+
+func TestConcurrentEnvStress(t *testing.T) {
+	base := makeConcurrentBase()
+	rule, err := parser.ParseExpr(`double(n) + limit * Scale`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g += 1 {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 50; i += 1 {
+				overlay := base.Overlay()
+				n := g * i
+				overlay.AddVar("n", reflect.ValueOf(&n))
+				if _, panik, errs := Interpret(`m := n + 1`, overlay); errs != nil || panik != nil {
+					t.Errorf("Failed to declare m: %v %v", errs, panik)
+					return
+				}
+				cexpr, errs := CheckExpr(rule, overlay)
+				if errs != nil {
+					t.Errorf("Failed to check rule: %v", errs)
+					return
+				}
+				results, err := EvalExpr(cexpr, overlay)
+				if err != nil {
+					t.Errorf("Failed to eval rule: %v", err)
+					return
+				} else if actual := results[0].Interface(); actual != 2 * n + 30 {
+					t.Errorf("Wrong result %v, expected %d", actual, 2 * n + 30)
+					return
+				}
+			}
+		}(g)
+	}
+	// Declarations added to the base concurrently do not disturb readers
+	for i := 0; i < 100; i += 1 {
+		base.AddFunc(fmt.Sprintf("f%d", i), reflect.ValueOf(func() {}))
+	}
+	wg.Wait()
+
+	// Goroutines sharing a scope declare variables within it concurrently
+	overlay := base.Overlay()
+	expectInterp(t, `results := make(chan int, 8)`, overlay)
+	expectInterp(t, `for i := 0; i < 8; i += 1 { go func(i int) { j := double(i); results <- j }(i) }`, overlay)
+	expectInterp(t, `sum := 0`, overlay)
+	expectInterp(t, `for i := 0; i < 8; i += 1 { sum += <-results }`, overlay)
+	expectResult(t, `sum`, overlay, 56)
+}
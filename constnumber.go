@@ -75,6 +75,20 @@ func NewConstComplex128(c complex128) *ConstNumber {
 	return z
 }
 
+// Returns the constant re + im*i of type t, where re and im are integers
+// or fractions such as "1/3". Generated package Envs use this to declare
+// exact untyped constants. Panics if re or im is invalid.
+func NewConstNumber(t ConstType, re, im string) *ConstNumber {
+	z := new(ConstNumber)
+	z.Type = t
+	if _, ok := z.Value.Re.SetString(re); !ok {
+		panic("eval: invalid constant " + re)
+	} else if _, ok := z.Value.Im.SetString(im); !ok {
+		panic("eval: invalid constant " + im)
+	}
+	return z
+}
+
 func (z *ConstNumber) String() string {
 	return z.StringShow0i(true)
 }
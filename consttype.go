@@ -234,7 +234,7 @@ func convertConstToTyped(from ConstType, c constValue, to reflect.Type, isTypeCa
 			v.SetInt(i)
 			return constValue(v), errs
 
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 			var errs []error
 			u, truncation, overflow := underlying.Value.Uint(to.Bits())
 			if truncation {
@@ -331,7 +331,7 @@ func convertTypedToConstNumber(v reflect.Value) (_ *ConstNumber, ok bool) {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return NewConstInt64(v.Int()), true
 
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 		return NewConstUint64(v.Uint()), true
 
 	case reflect.Float32, reflect.Float64:
@@ -95,7 +95,7 @@ func REPL(env *eval.SimpleEnv) {
 				} else {
 					fmt.Printf("Kind = Type = %v\n", kind)
 				}
-				fmt.Printf("results[%d] = %s\n", exprs, eval.Inspect(value))
+				fmt.Printf("results[%d] = %s\n", exprs, eval.InspectTyped(value, cexpr.KnownType()[0]))
 				exprs += 1
 				results = append(results, (vals)[0].Interface())
 			} else {
@@ -105,7 +105,7 @@ func REPL(env *eval.SimpleEnv) {
 			fmt.Printf("Kind = Multi-Value\n")
 			size := len(vals)
 			for i, v := range vals {
-				fmt.Printf("%s", eval.Inspect(v))
+				fmt.Printf("%s", eval.InspectTyped(v, cexpr.KnownType()[i]))
 				if i < size-1 { fmt.Printf(", ") }
 			}
 			fmt.Printf("\n")
@@ -181,6 +181,11 @@ func makeBogusEnv() *eval.SimpleEnv {
 	mainEnv := eval.MakeSimpleEnv()
 	mainEnv.Pkgs = pkgs
 
+	// Also allow the packages to be imported, e.g. import f "fmt"
+	for path, pkg := range pkgs {
+		eval.RegisterPackage(path, pkg)
+	}
+
 	// Some "alice" things for testing
 	type Alice struct {
 		Bob int
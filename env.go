@@ -2,6 +2,7 @@ package eval
 
 import (
 	"reflect"
+	"sync"
 )
 
 type envSource int
@@ -12,7 +13,22 @@ const (
 	envConst
 )
 
-// A Environment used for evaluation
+// A Environment used for evaluation. Goroutines started by go statements
+// share the scopes they close over, so an Env may be used concurrently.
+//
+// The checker and evaluator only add declarations to the scope they are
+// given and to scopes they push, which are never shared until a function
+// literal or go statement closes over them. Scopes further down are only
+// read through Var, Const, Func, Type and Pkg, and walked with PopScope, so
+// concurrent evaluations sharing a base Env call only these methods on it.
+// The exception is AddPkg, called by import declarations, which a
+// SimpleEnv applies to its root scope but a ConcurrentEnv applies to the
+// nearest overlay. The Add methods of a scope may also be called while
+// other goroutines look up identifiers in it, when a go statement or
+// function literal closes over the scope. Both SimpleEnv and ConcurrentEnv
+// are safe for such use, but only the lookups of ConcurrentEnv are
+// lock-free. Reading a variable also looks up its type in the registries of
+// interpreted named and interface types, which are lock-free for reads too.
 type Env interface {
 	// Return a pointer value to the variable ident if defined in the top scope, or reflect.Value{} otherwise
 	Var(ident string) reflect.Value
@@ -72,21 +88,35 @@ type SimpleEnv struct {
 	Consts map[string]reflect.Value
 	Types map[string]reflect.Type
 	Pkgs map[string]Env
+
+	// Resolves imports if set on the root scope, otherwise DefaultRegistry is used
+	Registry *Registry
+
+	// Guards the maps above when accessed through SimpleEnv's methods
+	mu sync.RWMutex
 }
 
 func (env *SimpleEnv) Var(ident string) reflect.Value {
+	env.mu.RLock()
+	defer env.mu.RUnlock()
 	return env.Vars[ident]
 }
 
 func (env *SimpleEnv) Func(ident string) reflect.Value {
+	env.mu.RLock()
+	defer env.mu.RUnlock()
 	return env.Funcs[ident]
 }
 
 func (env *SimpleEnv) Const(ident string) reflect.Value {
+	env.mu.RLock()
+	defer env.mu.RUnlock()
 	return env.Consts[ident]
 }
 
 func (env *SimpleEnv) Type(ident string) reflect.Type {
+	env.mu.RLock()
+	defer env.mu.RUnlock()
 	return env.Types[ident]
 }
 
@@ -94,6 +124,8 @@ func (env *SimpleEnv) Pkg(pkg string) Env {
 	for env.Parent != nil {
 		env = env.Parent
 	}
+	env.mu.RLock()
+	defer env.mu.RUnlock()
 	return env.Pkgs[pkg]
 }
 
@@ -112,18 +144,26 @@ func (env *SimpleEnv) PopScope() Env {
 }
 
 func (env *SimpleEnv) AddVar(ident string, v reflect.Value) {
+	env.mu.Lock()
+	defer env.mu.Unlock()
 	env.Vars[ident] = v
 }
 
 func (env *SimpleEnv) AddFunc(ident string, f reflect.Value) {
+	env.mu.Lock()
+	defer env.mu.Unlock()
 	env.Funcs[ident] = f
 }
 
 func (env *SimpleEnv) AddConst(ident string, c reflect.Value) {
+	env.mu.Lock()
+	defer env.mu.Unlock()
 	env.Consts[ident] = c
 }
 
 func (env *SimpleEnv) AddType(ident string, t reflect.Type) {
+	env.mu.Lock()
+	defer env.mu.Unlock()
 	env.Types[ident] = t
 }
 
@@ -131,5 +171,7 @@ func (env *SimpleEnv) AddPkg(pkg string, p Env) {
 	for env.Parent != nil {
 		env = env.Parent
 	}
+	env.mu.Lock()
+	defer env.mu.Unlock()
 	env.Pkgs[pkg] = p
 }
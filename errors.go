@@ -3,6 +3,7 @@ package eval
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"go/token"
 )
@@ -23,6 +24,10 @@ type ErrUndefinedFieldOrMethod struct {
 	Expr
 }
 
+type ErrMethodExprPtrReceiver struct {
+	*SelectorExpr
+}
+
 type ErrCallNonFuncType struct {
 	Expr
 }
@@ -247,6 +252,39 @@ type ErrBuiltinWrongArgType struct {
 	call *CallExpr
 }
 
+type ErrDuplicateTypeCase struct {
+	Expr
+}
+
+type ErrComparableOutsideConstraint struct {
+	*Ident
+}
+
+type ErrCloseNonChan struct {
+	Expr
+	call *CallExpr
+}
+
+type ErrCloseRecvOnlyChan struct {
+	Expr
+	call *CallExpr
+}
+
+type ErrClearNonMapOrSlice struct {
+	Expr
+	call *CallExpr
+}
+
+type ErrPrintUnprintable struct {
+	Expr
+	call *CallExpr
+}
+
+type ErrMinMaxUnordered struct {
+	Expr
+	call *CallExpr
+}
+
 type ErrBuiltinMismatchedArgs struct {
 	*CallExpr
 	x, y reflect.Type
@@ -344,6 +382,217 @@ type ErrImpossibleTypeCase struct {
 	tag Expr
 }
 
+type ErrCannotRangeOver struct {
+	Expr
+}
+
+type ErrTooManyRangeVars struct {
+	Expr
+}
+
+type ErrBadRangeAssign struct {
+	Expr
+	t reflect.Type
+}
+
+type ErrNonConstInitializer struct {
+	Expr
+}
+
+type ErrDeclCountMismatch struct {
+	*ValueSpec
+	lhs, rhs int
+}
+
+type ErrBadDeclValue struct {
+	Expr
+	t reflect.Type
+}
+
+type ErrNonConstArrayBound struct {
+	Expr
+}
+
+type ErrInvalidArrayBound struct {
+	Expr
+}
+
+type ErrNegativeArrayBound struct {
+	Expr
+}
+
+type ErrArrayBoundTooLarge struct {
+	Expr
+}
+
+type ErrArrayEllipsisOutsideLit struct {
+	*ArrayType
+}
+
+type ErrBadFuncTypeEllipsis struct {
+	*Ellipsis
+}
+
+type ErrDuplicateMethod struct {
+	Expr
+	name string
+}
+
+type ErrEmbeddedNonInterface struct {
+	Expr
+}
+
+type ErrDuplicateFieldDecl struct {
+	*Ident
+}
+
+type ErrInvalidEmbeddedField struct {
+	Expr
+}
+
+type ErrUnexportedEmbeddedField struct {
+	Expr
+}
+
+type ErrBadSelectCase struct {
+	*CommClause
+}
+
+type ErrInvalidSendTo struct {
+	*SendStmt
+}
+
+type ErrBadSendValue struct {
+	Expr
+	t reflect.Type
+}
+
+type ErrDeferOutsideFunc struct {
+	*DeferStmt
+}
+
+type ErrDeferNonCall struct {
+	*CallExpr
+}
+
+type ErrDeferDiscardsResult struct {
+	*CallExpr
+}
+
+type ErrGoNonCall struct {
+	*CallExpr
+}
+
+type ErrGoDiscardsResult struct {
+	*CallExpr
+}
+
+type ErrLabelNotDefined struct {
+	*BranchStmt
+}
+
+type ErrLabelRedefined struct {
+	*LabeledStmt
+}
+
+type ErrLabelNotUsed struct {
+	*LabeledStmt
+}
+
+type ErrInvalidBranchLabel struct {
+	*BranchStmt
+}
+
+type ErrBranchOutsideLoop struct {
+	*BranchStmt
+}
+
+type ErrGotoIntoBlock struct {
+	*BranchStmt
+}
+
+type ErrGotoOverDecl struct {
+	*BranchStmt
+}
+
+type ErrMisplacedFallthrough struct {
+	*BranchStmt
+}
+
+type ErrFallthroughFinalCase struct {
+	*BranchStmt
+}
+
+type ErrFallthroughTypeSwitch struct {
+	*BranchStmt
+}
+
+type ErrInvalidReceiver struct {
+	Expr
+}
+
+type ErrNonLocalReceiver struct {
+	Expr
+}
+
+type ErrFieldAndMethod struct {
+	*Ident
+	t reflect.Type
+}
+
+type ErrRedeclared struct {
+	*Ident
+}
+
+type ErrInvalidImportPath struct {
+	path string
+}
+
+type ErrUnregisteredImport struct {
+	path string
+}
+
+type ErrUninstantiatedGeneric struct {
+	Expr
+	generic *Generic
+}
+
+type ErrNotGeneric struct {
+	Expr
+}
+
+type ErrNonTypeArg struct {
+	Expr
+}
+
+type ErrWrongNumberOfTypeArgs struct {
+	*IndexListExpr
+	generic *Generic
+}
+
+type ErrNoInstance struct {
+	*IndexListExpr
+}
+
+type ErrCannotInferTypeArgs struct {
+	*IndexListExpr
+}
+
+type ErrAmbiguousTypeArgs struct {
+	*IndexListExpr
+}
+
+type ErrRecursiveType struct {
+	*Ident
+	// The type refers to itself only through pointers, slices and other
+	// types which reflect cannot create recursively
+	unsupported bool
+}
+
+type ErrInitCycle struct {
+	names []string
+}
+
 func (err ErrBadBasicLit) Error() string {
 	return fmt.Sprintf("Bad literal %v", err.BasicLit)
 }
@@ -384,10 +633,19 @@ func (err ErrInvalidIndirect) Error() string {
 func (err ErrUndefinedFieldOrMethod) Error() string {
 	selector := err.Expr.(*SelectorExpr)
 	t := selector.X.KnownType()[0]
+	if selector.isMethodExpr {
+		return fmt.Sprintf("%v undefined (type %v has no method %v)",
+			selector, t, selector.Sel.Name)
+	}
 	return fmt.Sprintf("%v undefined (type %v has no field or method %v)",
 		selector, t, selector.Sel.Name)
 }
 
+func (err ErrMethodExprPtrReceiver) Error() string {
+	return fmt.Sprintf("invalid method expression %v (needs pointer receiver: (*%v).%v)",
+		err.SelectorExpr, err.X, err.Sel.Name)
+}
+
 func (err ErrMissingValue) Error() string {
 	return fmt.Sprintf("%v used as value", err.Expr)
 }
@@ -428,8 +686,12 @@ func (err ErrIndexOutOfBounds) Error() string {
 		length = x.Const().Len()
 		xname = "string"
 		eltname = "byte"
+	} else if t := x.KnownType()[0]; t.Kind() == reflect.Ptr {
+		length = t.Elem().Len()
+		xname = "array"
+		eltname = "element"
 	} else {
-		length = x.KnownType()[0].Len()
+		length = t.Len()
 		xname = "array"
 		eltname = "element"
 	}
@@ -624,6 +886,8 @@ func (err ErrInvalidBinaryOperation) Error() string {
 				return fmt.Sprintf("invalid operation: %v (struct containing %v cannot be compared)",
 					binary, field.Type)
 			}
+		} else if !mismatch && xt.Kind() == reflect.Array && !isStaticTypeComparable(xt) {
+			return fmt.Sprintf("invalid operation: %v (%v cannot be compared)", binary, xt)
 		} else if !mismatch && comparableToNilOnly(xt) {
 			return fmt.Sprintf("invalid operation: %v (%v can only be compared to nil)",
 				binary, sprintOperandType(xt))
@@ -791,12 +1055,12 @@ func (err ErrInvalidTypeAssert) Error() string {
 
 func (err ErrImpossibleTypeAssert) Error() string {
 	assert := err.TypeAssertExpr
-	iT := assert.KnownType()[0]
-	xT := assert.X.KnownType()[0]
+	t := assert.KnownType()[0]
+	iT := assert.X.KnownType()[0]
 
 	return fmt.Sprintf("impossible type assertion:\n" +
 		"\t%v does not implement %v (missing %s method)",
-		xT, iT, missingMethod(iT, xT))
+		t, iT, missingMethod(iT, t))
 }
 
 func (err ErrMissingCompositeLitType) Error() string {
@@ -846,6 +1110,8 @@ func (err ErrBuiltinWrongNumberOfArgs) Error() string {
 	case "append":
 		// Note the s on arguments, which
 		return "missing arguments to append"
+	case "min", "max":
+		return fmt.Sprintf("not enough arguments for %v (expected 1, found 0)", uc(call))
 	default:
 		cause = fmt.Sprintf(": %v", uc(call))
 		tooMany = len(call.Args) != 0
@@ -885,6 +1151,41 @@ func (err ErrBuiltinWrongArgType) Error() string {
 	}
 }
 
+func (err ErrDuplicateTypeCase) Error() string {
+	return fmt.Sprintf("duplicate case %v in type switch", err.Expr)
+}
+
+func (err ErrComparableOutsideConstraint) Error() string {
+	return "cannot use type comparable outside a type constraint: interface is (or embeds) comparable"
+}
+
+func (err ErrCloseNonChan) Error() string {
+	t := err.Expr.KnownType()[0]
+	return fmt.Sprintf("invalid operation: %v (non-chan type %v)", uc(err.call), defaultPromotion(t))
+}
+
+func (err ErrCloseRecvOnlyChan) Error() string {
+	return fmt.Sprintf("invalid operation: %v (cannot close receive-only channel)", uc(err.call))
+}
+
+func (err ErrClearNonMapOrSlice) Error() string {
+	t := err.Expr.KnownType()[0]
+	return fmt.Sprintf("invalid argument %v (type %v) for clear: argument must be map or slice",
+		uc(err.Expr), defaultPromotion(t))
+}
+
+func (err ErrPrintUnprintable) Error() string {
+	ident := err.call.Fun.(*Ident)
+	return fmt.Sprintf("illegal types for operand: %s\n\t%v", ident.Name, err.Expr.KnownType()[0])
+}
+
+func (err ErrMinMaxUnordered) Error() string {
+	ident := err.call.Fun.(*Ident)
+	t := err.Expr.KnownType()[0]
+	return fmt.Sprintf("invalid argument %v (type %v) for %s: cannot be ordered",
+		uc(err.Expr), defaultPromotion(t), ident.Name)
+}
+
 func (err ErrBuiltinMismatchedArgs) Error() string {
 	call := err.CallExpr
 	call = uc(call).(*CallExpr)
@@ -1030,9 +1331,262 @@ func (err ErrImpossibleTypeCase) Error() string {
 		err.tag, iT, err.Expr, missingMethod(iT, xT))
 }
 
+func (err ErrCannotRangeOver) Error() string {
+	return fmt.Sprintf("cannot range over %v (type %v)", err.Expr, err.Expr.KnownType()[0])
+}
+
+func (err ErrTooManyRangeVars) Error() string {
+	return fmt.Sprintf("too many variables in range over %v", err.Expr)
+}
+
+func (err ErrBadRangeAssign) Error() string {
+	return fmt.Sprintf("cannot assign type %v to %v (type %v) in range",
+		err.t, err.Expr, err.Expr.KnownType()[0])
+}
+
+func (err ErrNonConstInitializer) Error() string {
+	return fmt.Sprintf("const initializer %v is not a constant", err.Expr)
+}
+
+func (err ErrDeclCountMismatch) Error() string {
+	if err.tok == token.VAR {
+		return fmt.Sprintf("assignment count mismatch: %d = %d", err.lhs, err.rhs)
+	} else if err.rhs == 0 {
+		return "missing init expr for const declaration"
+	} else if err.lhs > err.rhs {
+		return "missing value in const declaration"
+	} else {
+		return "extra expression in const declaration"
+	}
+}
+
+func (err ErrBadDeclValue) Error() string {
+	kt := err.Expr.KnownType()
+	if len(kt) > 1 {
+		return fmt.Sprintf("cannot assign %v to type %v in multiple assignment", err.Expr, err.t)
+	}
+	return fmt.Sprintf("cannot use %v (type %v) as type %v in assignment",
+		err.Expr, defaultPromotion(kt[0]), err.t)
+}
+
+func (err ErrNonConstArrayBound) Error() string {
+	return fmt.Sprintf("non-constant array bound %v", err.Expr)
+}
+
+func (err ErrInvalidArrayBound) Error() string {
+	return fmt.Sprintf("invalid array bound %v", err.Expr)
+}
+
+func (err ErrNegativeArrayBound) Error() string {
+	return "array bound must be non-negative"
+}
+
+func (err ErrArrayBoundTooLarge) Error() string {
+	return "array bound is too large"
+}
+
+func (err ErrArrayEllipsisOutsideLit) Error() string {
+	return "use of [...] array outside of array literal"
+}
+
+func (err ErrBadFuncTypeEllipsis) Error() string {
+	return "can only use ... with final parameter in list"
+}
+
+func (err ErrDuplicateMethod) Error() string {
+	return fmt.Sprintf("duplicate method %s", err.name)
+}
+
+func (err ErrEmbeddedNonInterface) Error() string {
+	return fmt.Sprintf("interface contains embedded non-interface %v", err.Expr)
+}
+
+func (err ErrDuplicateFieldDecl) Error() string {
+	return fmt.Sprintf("duplicate field %v", err.Ident)
+}
+
+func (err ErrInvalidEmbeddedField) Error() string {
+	t := err.Expr.KnownType()[0]
+	if t.Kind() == reflect.Ptr {
+		switch t.Elem().Kind() {
+		case reflect.Interface:
+			return "embedded type cannot be a pointer to interface"
+		case reflect.Ptr:
+			return "embedded type cannot be a pointer"
+		}
+	}
+	return fmt.Sprintf("invalid embedded field type %v", err.Expr)
+}
+
+func (err ErrUnexportedEmbeddedField) Error() string {
+	return fmt.Sprintf("embedded field of unexported type %v not supported by the interpreter", err.Expr)
+}
+
+func (err ErrBadSelectCase) Error() string {
+	return "select case must be receive, send or assign recv"
+}
+
+func (err ErrInvalidSendTo) Error() string {
+	send := err.SendStmt
+	t := send.Chan.KnownType()[0]
+	var cause string
+	if t.Kind() != reflect.Chan {
+		cause = fmt.Sprintf("send to non-chan type %v", t)
+	} else {
+		cause = fmt.Sprintf("send to receive-only type %v", t)
+	}
+	return fmt.Sprintf("invalid operation: %v <- %v (%s)", send.Chan, send.Value, cause)
+}
+
+func (err ErrBadSendValue) Error() string {
+	t := err.Expr.KnownType()[0]
+	if t == ConstNil {
+		return fmt.Sprintf("cannot use nil as type %v in send", err.t)
+	}
+	return fmt.Sprintf("cannot use %v (type %v) as type %v in send", err.Expr, defaultPromotion(t), err.t)
+}
+
+func (err ErrDeferOutsideFunc) Error() string {
+	return "defer outside function"
+}
+
+func (err ErrDeferNonCall) Error() string {
+	return "defer requires function call, not conversion"
+}
+
+func (err ErrDeferDiscardsResult) Error() string {
+	return fmt.Sprintf("defer discards result of %v", err.CallExpr)
+}
+
+func (err ErrGoNonCall) Error() string {
+	return "go requires function call, not conversion"
+}
+
+func (err ErrGoDiscardsResult) Error() string {
+	return fmt.Sprintf("go discards result of %v", err.CallExpr)
+}
+
+func (err ErrLabelNotDefined) Error() string {
+	return fmt.Sprintf("label %v not defined", err.Label)
+}
+
+func (err ErrLabelRedefined) Error() string {
+	return fmt.Sprintf("label %v already defined", err.Label)
+}
+
+func (err ErrLabelNotUsed) Error() string {
+	return fmt.Sprintf("label %v defined and not used", err.Label)
+}
+
+func (err ErrInvalidBranchLabel) Error() string {
+	return fmt.Sprintf("invalid %v label %v", err.Tok, err.Label)
+}
+
+func (err ErrBranchOutsideLoop) Error() string {
+	if err.Tok == token.CONTINUE {
+		return "continue is not in a loop"
+	}
+	return "break is not in a loop, switch, or select"
+}
+
+func (err ErrGotoIntoBlock) Error() string {
+	return fmt.Sprintf("goto %v jumps into block", err.Label)
+}
+
+func (err ErrGotoOverDecl) Error() string {
+	return fmt.Sprintf("goto %v jumps over variable declaration", err.Label)
+}
+
+func (err ErrMisplacedFallthrough) Error() string {
+	return "fallthrough statement out of place"
+}
+
+func (err ErrFallthroughFinalCase) Error() string {
+	return "cannot fallthrough final case in switch"
+}
+
+func (err ErrFallthroughTypeSwitch) Error() string {
+	return "cannot fallthrough in type switch"
+}
+
+func (err ErrInvalidReceiver) Error() string {
+	return fmt.Sprintf("invalid receiver type %v", err.Expr.KnownType()[0])
+}
+
+func (err ErrNonLocalReceiver) Error() string {
+	return fmt.Sprintf("cannot define new methods on non-local type %v", err.Expr.KnownType()[0])
+}
+
+func (err ErrFieldAndMethod) Error() string {
+	return fmt.Sprintf("type %v has both field and method named %v", err.t, err.Ident)
+}
+
+func (err ErrRedeclared) Error() string {
+	return fmt.Sprintf("%v redeclared in this block", err.Ident)
+}
+
+func (err ErrInvalidImportPath) Error() string {
+	return fmt.Sprintf("invalid import path: %s", err.path)
+}
+
+func (err ErrUnregisteredImport) Error() string {
+	return fmt.Sprintf("could not import %s (no package registered with this import path)", err.path)
+}
+
+func (err ErrUninstantiatedGeneric) Error() string {
+	kind := "function"
+	if err.generic.isType {
+		kind = "type"
+	}
+	return fmt.Sprintf("cannot use generic %s %v without instantiation", kind, err.Expr)
+}
+
+func (err ErrNotGeneric) Error() string {
+	return fmt.Sprintf("invalid operation: %v is not a generic function or type", err.Expr)
+}
+
+func (err ErrNonTypeArg) Error() string {
+	return fmt.Sprintf("%v is not a type", uc(err.Expr))
+}
+
+func (err ErrWrongNumberOfTypeArgs) Error() string {
+	return fmt.Sprintf("got %d type arguments but %v has %d type parameters",
+		len(err.Indices), err.X, err.generic.numTypeParams())
+}
+
+func (err ErrNoInstance) Error() string {
+	return fmt.Sprintf("%v is not instantiated by the host", err.IndexListExpr)
+}
+
+func (err ErrCannotInferTypeArgs) Error() string {
+	return fmt.Sprintf("cannot infer type arguments of %v", err.IndexListExpr)
+}
+
+func (err ErrAmbiguousTypeArgs) Error() string {
+	return fmt.Sprintf("cannot infer type arguments of %v: more than one instantiation matches", err.IndexListExpr)
+}
+
+func (err ErrRecursiveType) Error() string {
+	if err.unsupported {
+		return fmt.Sprintf("invalid recursive type %v (not supported by the interpreter)", err.Ident)
+	}
+	return fmt.Sprintf("invalid recursive type %v", err.Ident)
+}
+
+func (err ErrInitCycle) Error() string {
+	if len(err.names) == 1 {
+		return fmt.Sprintf("initialization cycle: %s refers to itself", err.names[0])
+	}
+	refs := make([]string, len(err.names))
+	for i, name := range err.names {
+		refs[i] = fmt.Sprintf("%s refers to %s", name, err.names[(i + 1) % len(err.names)])
+	}
+	return "initialization cycle: " + strings.Join(refs, ", ")
+}
+
 // Determines if two types can be automatically converted between.
 func areTypesCompatible(xt, yt reflect.Type) bool {
-	return xt.AssignableTo(unhackType(yt)) || yt.AssignableTo(unhackType(xt))
+	return typeAssignableTo(xt, yt) || typeAssignableTo(yt, xt)
 }
 
 func sprintOperandType(t reflect.Type) string {
@@ -1054,12 +1608,14 @@ func sprintOperandType(t reflect.Type) string {
 	}
 }
 
+// Returns the first method of iT which xT is either missing or has
+// with the wrong signature, or "" if xT implements iT.
 func missingMethod(iT, xT reflect.Type) (method string) {
 	numMethod := iT.NumMethod()
 	for i := 0; i < numMethod; i += 1 {
-		method = iT.Method(i).Name
-		if _, ok := xT.MethodByName(method); !ok {
-			return
+		m := iT.Method(i)
+		if sig, ok := methodSignature(xT, m.Name); !ok || sig != m.Type {
+			return m.Name
 		}
 	}
 	return ""
@@ -7,6 +7,7 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/scanner"
+	"go/token"
 )
 
 var emptyEnv Env = MakeSimpleEnv()
@@ -30,7 +31,15 @@ func EvalEnv(expr string, env Env) (result []reflect.Value, panik error, compile
 }
 
 func Interpret(stmt string, env Env) (result []reflect.Value, panik error, compileErrors []error) {
-	if s, err := ParseStmt(stmt); err != nil {
+	_, result, panik, compileErrors = interpret(stmt, env)
+	return
+}
+
+// Interpret stmt in env, also returning the parsed statement, or nil if
+// stmt could not be parsed.
+func interpret(stmt string, env Env) (node ast.Stmt, result []reflect.Value, panik error, compileErrors []error) {
+	var err error
+	if node, err = ParseStmt(stmt); err != nil {
 		if errs, ok := err.(scanner.ErrorList); ok {
 			for i := range errs {
 				compileErrors = append(compileErrors, errs[i])
@@ -38,14 +47,15 @@ func Interpret(stmt string, env Env) (result []reflect.Value, panik error, compi
 		} else {
 			compileErrors = append(compileErrors, err)
 		}
-	} else if e, ok := s.(*ast.ExprStmt); ok {
+		node = nil
+	} else if e, ok := node.(*ast.ExprStmt); ok {
 		if cexpr, errs := CheckExpr(e.X, env); errs != nil {
 			compileErrors = errs
 		} else {
 			result, panik = EvalExpr(cexpr, env)
 		}
 	} else {
-		if cstmt, errs := CheckStmt(s, env); errs != nil {
+		if cstmt, errs := CheckStmt(node, env); errs != nil {
 			compileErrors = errs
 		} else {
 			_, panik = InterpStmt(cstmt, env)
@@ -62,11 +72,14 @@ func ParseStmt(stmt string) (ast.Stmt, error) {
 	// as expressions, and stmts and stmts. We try both.
 	// However, there is a bug in parser.ParseExpr that it does not detect excess input.
 	// Therefore, the _ of _ = 1 will be parsed as an expression. To avoid this, attempt
-	// to parse the input as a statement first, and fall back to an expression
+	// to parse the input as a statement first, and fall back to an expression.
+	// Method and import declarations are not statements, and are returned as a DeclStmt.
 	expr := "func(){" + stmt + ";}"
 	if e, err := parser.ParseExpr(expr); err != nil {
 		if e, err := parser.ParseExpr(stmt); err == nil {
 			return &ast.ExprStmt{X: e}, nil
+		} else if decl, ok := parseDecl(stmt); ok {
+			return &ast.DeclStmt{Decl: decl}, nil
 		}
 		errs := err.(scanner.ErrorList)
 		for i := range errs {
@@ -84,3 +97,19 @@ func ParseStmt(stmt string) (ast.Stmt, error) {
 	}
 }
 
+
+// Parse a single func or import declaration, such as a method
+// declaration, which cannot appear within a func literal.
+func parseDecl(src string) (ast.Decl, bool) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", "package main;" + src, 0)
+	if err != nil || len(file.Decls) != 1 {
+		return nil, false
+	}
+	switch decl := file.Decls[0].(type) {
+	case *ast.FuncDecl:
+		return decl, true
+	case *ast.GenDecl:
+		return decl, decl.Tok == token.IMPORT
+	}
+	return nil, false
+}
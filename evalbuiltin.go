@@ -29,6 +29,18 @@ func evalCallBuiltinExpr(call *CallExpr, env Env) ([]reflect.Value, error) {
 		return evalBuiltinDeleteExpr(call, env)
 	case "panic":
 		return evalBuiltinPanicExpr(call, env)
+	case "recover":
+		return evalBuiltinRecoverExpr(call, env)
+	case "close":
+		return evalBuiltinCloseExpr(call, env)
+	case "clear":
+		return evalBuiltinClearExpr(call, env)
+	case "print":
+		return evalBuiltinPrintExpr(call, env, false)
+	case "println":
+		return evalBuiltinPrintExpr(call, env, true)
+	case "min", "max":
+		return evalBuiltinMinMaxExpr(call, env)
 	default:
 		panic("eval: unimplemented builtin " + ident.Name)
 	}
@@ -193,3 +205,114 @@ func evalBuiltinPanicExpr(call *CallExpr, env Env) ([]reflect.Value, error) {
 	}
 }
 
+
+// recover only stops a panic when called directly by a deferred function,
+// as in defer func() { recover() }().
+func evalBuiltinRecoverExpr(call *CallExpr, env Env) ([]reflect.Value, error) {
+	return []reflect.Value{builtinRecover(envFrame(env))}, nil
+}
+
+func evalBuiltinCloseExpr(call *CallExpr, env Env) ([]reflect.Value, error) {
+	if x, err := EvalExpr(call.Args[0], env); err != nil {
+		return nil, err
+	} else {
+		return []reflect.Value{}, builtinClose(x[0])
+	}
+}
+
+func evalBuiltinClearExpr(call *CallExpr, env Env) ([]reflect.Value, error) {
+	if x, err := EvalExpr(call.Args[0], env); err != nil {
+		return nil, err
+	} else {
+		builtinClear(x[0])
+		return []reflect.Value{}, nil
+	}
+}
+
+func evalBuiltinPrintExpr(call *CallExpr, env Env, ln bool) ([]reflect.Value, error) {
+	args := make([]reflect.Value, len(call.Args))
+	for i, arg := range call.Args {
+		t := knownType{defaultPromotion(arg.KnownType()[0])}
+		if x, err := evalTypedExpr(arg, t, env); err != nil {
+			return nil, err
+		} else {
+			args[i] = x[0]
+		}
+	}
+	if ln {
+		builtinPrintln(args...)
+	} else {
+		builtinPrint(args...)
+	}
+	return []reflect.Value{}, nil
+}
+
+// Evaluate the arguments of a call to a builtin allowed as a statement,
+// for defer and go statements which evaluate them before the call is run.
+func evalBuiltinStmtArgs(call *CallExpr, env Env) ([]reflect.Value, error) {
+	name := call.Fun.(*Ident).Name
+	args := make([]reflect.Value, len(call.Args))
+	for i, arg := range call.Args {
+		var t knownType
+		switch {
+		case name == "delete" && i == 1:
+			t = knownType{call.Args[0].KnownType()[0].Key()}
+		case name == "panic":
+			t = knownType{emptyInterface}
+		case name == "print" || name == "println":
+			t = knownType{defaultPromotion(arg.KnownType()[0])}
+		}
+		var x []reflect.Value
+		var err error
+		if t != nil {
+			x, err = evalTypedExpr(arg, t, env)
+		} else {
+			x, err = EvalExpr(arg, env)
+		}
+		if err != nil {
+			return nil, err
+		}
+		args[i] = x[0]
+	}
+	return copyValues(args), nil
+}
+
+// Run a call to a builtin allowed as a statement with arguments evaluated
+// by evalBuiltinStmtArgs. The frame of env is used by recover.
+func callBuiltinStmt(call *CallExpr, args []reflect.Value, env Env) error {
+	switch name := call.Fun.(*Ident).Name; name {
+	case "copy":
+		builtinCopy(args[0], args[1])
+	case "delete":
+		builtinDelete(args[0], args[1])
+	case "panic":
+		return builtinPanic(args[0])
+	case "recover":
+		builtinRecover(envFrame(env))
+	case "close":
+		return builtinClose(args[0])
+	case "clear":
+		builtinClear(args[0])
+	case "print":
+		builtinPrint(args...)
+	case "println":
+		builtinPrintln(args...)
+	default:
+		panic("eval: builtin " + name + " not allowed as a statement")
+	}
+	return nil
+}
+
+func evalBuiltinMinMaxExpr(call *CallExpr, env Env) ([]reflect.Value, error) {
+	t := call.KnownType()
+	args := make([]reflect.Value, len(call.Args))
+	for i, arg := range call.Args {
+		if x, err := evalTypedExpr(arg, t, env); err != nil {
+			return nil, err
+		} else {
+			args[i] = x[0]
+		}
+	}
+	isMin := call.Fun.(*Ident).Name == "min"
+	return []reflect.Value{builtinMinMax(isMin, args...)}, nil
+}
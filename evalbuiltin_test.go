@@ -2,6 +2,9 @@ package eval
 
 
 import (
+	"bytes"
+	"io"
+	"math"
 	"reflect"
 	"testing"
 )
@@ -101,3 +104,60 @@ func TestBuiltinDelete(t *testing.T) {
 		t.Fatalf("Failed to delete(a, 1)`")
 	}
 }
+
+func TestBuiltinClose(t *testing.T) {
+	env := MakeSimpleEnv()
+	ch := make(chan int, 1)
+	env.Vars["ch"] = reflect.ValueOf(&ch)
+	expectInterp(t, "ch <- 1", env)
+	expectInterp(t, "close(ch)", env)
+	expectResults(t, "<-ch", env, 1, true)
+	expectResults(t, "<-ch", env, 0, false)
+	expectPanic(t, "close(ch)", env, "close of closed channel")
+	expectPanic(t, "close(chan int(nil))", env, "close of nil channel")
+}
+
+func TestBuiltinClear(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "m := map[string]int{\"a\": 1, \"b\": 2}", env)
+	expectInterp(t, "clear(m)", env)
+	expectResult(t, "len(m)", env, 0)
+	expectInterp(t, "s := []int{1, 2, 3}", env)
+	expectInterp(t, "clear(s[1:])", env)
+	expectResult(t, "s", env, []int{1, 0, 0})
+}
+
+func TestBuiltinPrint(t *testing.T) {
+	env := MakeSimpleEnv()
+	var out bytes.Buffer
+	defer func(w io.Writer) { PrintOutput = w }(PrintOutput)
+	PrintOutput = &out
+	expectInterp(t, "print(1, \"a\", true)", env)
+	expectInterp(t, "println(1, 'a', 1.5, -0.25)", env)
+	expected := "1atrue1 97 +1.500000e+000 -2.500000e-001\n"
+	if out.String() != expected {
+		t.Fatalf("Expected output %q, got %q", expected, out.String())
+	}
+}
+
+func TestBuiltinMinMax(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Vars["x"] = reflect.ValueOf(new(int))
+	f := new(float64)
+	env.Vars["f"] = reflect.ValueOf(f)
+	expectConst(t, "min(3, 1.5, 2)", env, NewConstFloat64(1.5), ConstFloat)
+	expectConst(t, "max(1, 'a')", env, NewConstRune('a'), ConstRune)
+	expectConst(t, "min(\"b\", \"a\", \"c\")", env, "a", ConstString)
+	expectConst(t, "max(int8(3), 4)", env, int8(4), reflect.TypeOf(int8(0)))
+	expectResult(t, "min(x, 3, -2)", env, -2)
+	expectResult(t, "max(x, 3, -2)", env, 3)
+	expectResult(t, "max(f, -1)", env, 0.0)
+	expectInterp(t, "f = -f", env)
+	if r := getResults(t, "min(0.0, f)", env)[0].Float(); !math.Signbit(r) {
+		t.Fatalf("Expected min(0.0, -0.0) to be -0.0, got %v", r)
+	}
+	*f = math.NaN()
+	if r := getResults(t, "max(1.0, f, 2.0)", env)[0].Float(); !math.IsNaN(r) {
+		t.Fatalf("Expected max with NaN to be NaN, got %v", r)
+	}
+}
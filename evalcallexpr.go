@@ -27,18 +27,35 @@ func evalCallTypeExpr(call *CallExpr, env Env) ([]reflect.Value, error) {
 	} else if v, err := EvalExpr(arg, env); err != nil {
 		return nil, nil
 	} else {
-		cast := v[0].Convert(unhackType(call.KnownType()[0]))
+		to := call.KnownType()[0]
+		if to.Kind() == reflect.Interface {
+			v[0] = interfaceValue(v[0], t, to)
+		}
+		cast := v[0].Convert(unhackType(to))
 		return []reflect.Value{cast}, nil
 	}
 }
 
 func evalCallFunExpr(call *CallExpr, env Env) ([]reflect.Value, error) {
-	v, err := EvalExpr(call.Fun, env)
+	fun, args, err := evalCallFunArgs(call, env)
 	if err != nil {
 		return nil, err
 	}
+	return callFun(call, fun, args, nil)
+}
 
-	fun := v[0]
+// Evaluate the function value and arguments of a call, without calling it.
+func evalCallFunArgs(call *CallExpr, env Env) (reflect.Value, []reflect.Value, error) {
+	v, err := EvalExpr(call.Fun, env)
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+	args, err := evalCallArgs(call, v[0], env)
+	return v[0], args, err
+}
+
+// Evaluate the arguments of a call to fun.
+func evalCallArgs(call *CallExpr, fun reflect.Value, env Env) ([]reflect.Value, error) {
 	ft := fun.Type()
 	numIn := ft.NumIn()
 
@@ -76,11 +93,46 @@ func evalCallFunExpr(call *CallExpr, env Env) ([]reflect.Value, error) {
 		}
 	}
 
-	var out []reflect.Value
+	return args, nil
+}
+
+// Call fun, returning a panic of the call as an error. Interpreted funcs
+// are called directly, passing deferredBy, the frame deferring the call if
+// any, and host funcs are passed the plain values of Named types.
+func callFun(call *CallExpr, fun reflect.Value, args []reflect.Value, deferredBy *funcFrame) (out []reflect.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out, err = nil, panicError(r)
+		}
+	}()
+	if fun.IsNil() {
+		return nil, PanicInvalidDereference{}
+	} else if f := lookupInterpFunc(fun); f != nil {
+		return f.call(packArgs(call, fun.Type(), args), deferredBy), nil
+	}
+	args = hostArgs(fun.Type(), args, call.argNEllipsis)
 	if call.argNEllipsis {
-		out = fun.CallSlice(args)
+		return fun.CallSlice(args), nil
 	} else {
-		out = fun.Call(args)
+		return fun.Call(args), nil
+	}
+}
+
+// Convert args, as evaluated by evalCallArgs, to the arguments of the func
+// type ft as passed by reflect.MakeFunc.
+func packArgs(call *CallExpr, ft reflect.Type, args []reflect.Value) []reflect.Value {
+	in := make([]reflect.Value, ft.NumIn())
+	n := len(in)
+	if ft.IsVariadic() && !call.argNEllipsis {
+		n -= 1
+		in[n] = reflect.MakeSlice(ft.In(n), len(args) - n, len(args) - n)
+		for i, arg := range args[n:] {
+			in[n].Index(i).Set(arg)
+		}
+	}
+	for i := 0; i < n; i += 1 {
+		in[i] = reflect.New(ft.In(i)).Elem()
+		in[i].Set(args[i])
 	}
-	return out, nil
+	return in
 }
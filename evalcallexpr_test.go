@@ -1,8 +1,13 @@
 package eval
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
 	"testing"
 	"reflect"
+	"sync"
 )
 
 func TestFuncCallWithConst(t *testing.T) {
@@ -55,3 +60,286 @@ func TestTypeConversionWithEmptyInterface(t *testing.T) {
 	expectResult(t, "interface{}('a')", env, interface{}('a'))
 }
 
+
+type callHandlerFunc func(string) int
+
+func TestFuncLitCall(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectResult(t, "func(a, b int) int { return a * b }(3, 4)", env, 12)
+	expectResult(t, "func(int, string) bool { return true }(1, \"a\")", env, true)
+}
+
+func TestFuncLitVariadic(t *testing.T) {
+	env := MakeSimpleEnv()
+	expr := "func(xs ...int) int { return len(xs) }(1, 2, 3)"
+	expectResult(t, expr, env, 3)
+}
+
+func TestFuncTypeConversion(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Types["HandlerFunc"] = reflect.TypeOf(callHandlerFunc(nil))
+	results := getResults(t, "HandlerFunc(func(s string) int { return len(s) })", env)
+	if f, ok := results[0].Interface().(callHandlerFunc); !ok || f("abc") != 3 {
+		t.Fatalf("Expected a callHandlerFunc returning 3, got %v", results[0])
+	}
+	expectResult(t, "func(string) int(nil) == nil", env, true)
+}
+
+func TestFuncTypeComposite(t *testing.T) {
+	env := MakeSimpleEnv()
+	expr := `map[string]func(int) int{"double": func(x int) int { return 2 * x }}["double"](4)`
+	expectResult(t, expr, env, 8)
+	expectResult(t, "len([]func(){ func() {}, nil })", env, 2)
+}
+
+func TestFuncLitRecursion(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "var fib func(int) int", env)
+	expectInterp(t, "fib = func(n int) int { if n < 2 { return n }; return fib(n-1) + fib(n-2) }", env)
+	expectResult(t, "fib(10)", env, 55)
+
+	expectInterp(t, "var sum func(int) int", env)
+	expectInterp(t, "sum = func(n int) (r int) { if n > 0 { r = sum(n-1) }; r += n; return r }", env)
+	expectResult(t, "sum(4)", env, 10)
+}
+
+func TestFuncLitConcurrentCalls(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "square := func(n int) int { for i := 0; i < 100; i += 1 { _ = n }; return n * n }", env)
+	f := getResults(t, "square", env)[0].Interface().(func(int) int)
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i += 1 {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if n := f(i); n != i*i {
+				t.Errorf("Expected square(%d) == %d, got %d", i, i*i, n)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestFuncLitCapturedVars(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 0", env)
+	expectInterp(t, "inc := func() int { x += 1; return x }", env)
+	expectResult(t, "inc()", env, 1)
+	expectResult(t, "inc()", env, 2)
+	expectResult(t, "x", env, 2)
+
+	expectInterp(t, "counter := func() func() int { n := 0; return func() int { n += 1; return n } }", env)
+	expectInterp(t, "a, b := counter(), counter()", env)
+	expectResult(t, "a()", env, 1)
+	expectResult(t, "a()", env, 2)
+	expectResult(t, "b()", env, 1)
+}
+
+func TestFuncLitDefer(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Vars["s"] = reflect.ValueOf(new([]int))
+	expectInterp(t, "f := func(n int) { for i := 0; i < n; i += 1 { defer func(i int) { s = append(s, i) }(i) } }", env)
+	expectInterp(t, "f(3)", env)
+	expectResult(t, "s", env, []int{2, 1, 0})
+
+	expectInterp(t, "s = nil", env)
+	expectInterp(t, "g := func(x int) int { defer func() { s = append(s, x) }(); x = 2; if x > 1 { return x }; return 0 }", env)
+	expectResult(t, "g(1)", env, 2)
+	expectResult(t, "s", env, []int{2})
+}
+
+func TestFuncLitDeferNamedResults(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectResult(t, "func() (x int) { defer func() { x *= 2 }(); return 21 }()", env, 42)
+	expectResults(t, "func() (x, y int) { x, y = 1, 2; return y, x }()", env, 2, 1)
+}
+
+func TestFuncLitRecover(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Vars["s"] = reflect.ValueOf(new([]int))
+	expr := `func() (r interface{}) {
+		defer func() { r = recover() }()
+		defer func() { s = append(s, 1) }()
+		panic("boom")
+	}()`
+	expectResult(t, expr, env, "boom")
+	expectResult(t, "s", env, []int{1})
+
+	expr = `func(xs []int, i int) (x int, err interface{}) {
+		defer func() {
+			if r := recover(); r != nil {
+				x, err = -1, r
+			}
+		}()
+		return xs[i], nil
+	}([]int{1}, 2)`
+	results := getResults(t, expr, env)
+	if x := results[0].Interface(); x != -1 {
+		t.Fatalf("Expected x == -1, got %v", x)
+	} else if _, ok := results[1].Interface().(PanicIndexOutOfBounds); !ok {
+		t.Fatalf("Expected err PanicIndexOutOfBounds, got %v", results[1])
+	}
+
+	// recover outside of a deferred call does nothing
+	expectResult(t, "func() interface{} { return recover() }() == nil", env, true)
+	expectPanic(t, "func() int { defer func() { _ = 1 }(); panic(\"boom\") }()", env, "boom")
+}
+
+func TestFuncLitRecoverDeferredValue(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "h := func() { recover() }", env)
+	expectResult(t, `func() (x int) { defer h(); x = 1; panic("p") }()`, env, 1)
+
+	// recover must be called directly by the deferred function
+	expectInterp(t, "g := func() { h() }", env)
+	expectPanic(t, `func() { defer g(); panic("p") }()`, env, "p")
+
+	expectInterp(t, "type T int", env)
+	expectInterp(t, "func (T) Recover() { recover() }", env)
+	expectInterp(t, "var v T", env)
+	expectResult(t, `func() (x int) { defer v.Recover(); x = 1; panic("p") }()`, env, 1)
+	expectResult(t, `func() (x int) { defer T.Recover(v); x = 1; panic("p") }()`, env, 1)
+
+	expectInterp(t, "k := func(xs ...int) { recover() }", env)
+	expectResult(t, `func() (x int) { defer k(1, 2); x = 1; panic("p") }()`, env, 1)
+}
+
+func TestFuncLitDeferBuiltinArgs(t *testing.T) {
+	env := MakeSimpleEnv()
+	var out bytes.Buffer
+	defer func(w io.Writer) { PrintOutput = w }(PrintOutput)
+	PrintOutput = &out
+	expectInterp(t, "func() { x := 1; defer println(x); x = 2 }()", env)
+	if out.String() != "1\n" {
+		t.Fatalf("Expected output %q, got %q", "1\n", out.String())
+	}
+
+	expectInterp(t, "m := map[int]int{1: 1, 2: 2}", env)
+	expectInterp(t, "func() { k := 1; defer delete(m, k); k = 2 }()", env)
+	expectResult(t, "len(m)", env, 1)
+	expectResult(t, "m[2] == 2", env, true)
+}
+
+func TestFuncCallNamedHostArgs(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Funcs["Sprint"] = reflect.ValueOf(fmt.Sprint)
+	env.Funcs["Apply"] = reflect.ValueOf(func(f func() interface{}) string { return fmt.Sprint(f()) })
+	expectInterp(t, "type Celsius float64", env)
+	expectInterp(t, "type Ints []int", env)
+	expectInterp(t, "type P struct { X int }", env)
+	expectResult(t, "Sprint(Celsius(3))", env, "3")
+	expectResult(t, "Sprint(Ints{1, 2})", env, "[1 2]")
+	expectResult(t, "Sprint(P{2})", env, "{2}")
+	expectResult(t, "Sprint(&P{2})", env, "&{2}")
+
+	// Boxes held by interpreted interfaces are unwrapped for the host
+	expectInterp(t, "var i interface{} = Celsius(4)", env)
+	expectResult(t, "Sprint(i)", env, "4")
+	expectResult(t, "Sprint([]interface{}{Celsius(5), 6}...)", env, "5 6")
+	expectResult(t, "Apply(func() interface{} { return Celsius(7) })", env, "7")
+
+	pkg := MakeSimpleEnv()
+	var x interface{}
+	pkg.Vars["X"] = reflect.ValueOf(&x)
+	env.Pkgs["pkg"] = pkg
+	expectInterp(t, "pkg.X = Celsius(8)", env)
+	if x != 8.0 {
+		t.Fatalf("Expected pkg.X == 8.0, got %#v", x)
+	}
+}
+
+type stringerAdapter struct {
+	StringFunc func() string
+	Adapted interface{}
+}
+
+func (a stringerAdapter) String() string {
+	return a.StringFunc()
+}
+
+type sortAdapter struct {
+	LenFunc func() int
+	LessFunc func(i, j int) bool
+	SwapFunc func(i, j int)
+	Adapted interface{}
+}
+
+func (a *sortAdapter) Len() int           { return a.LenFunc() }
+func (a *sortAdapter) Less(i, j int) bool { return a.LessFunc(i, j) }
+func (a *sortAdapter) Swap(i, j int)      { a.SwapFunc(i, j) }
+
+func TestFuncCallAdapter(t *testing.T) {
+	stringerT := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	sortT := reflect.TypeOf((*sort.Interface)(nil)).Elem()
+	defer RegisterAdapter(stringerT, reflect.TypeOf(fmtStringerAdapter{}))
+	if err := RegisterAdapter(stringerT, reflect.TypeOf(stringerAdapter{})); err != nil {
+		t.Fatal(err)
+	} else if err := RegisterAdapter(sortT, reflect.TypeOf(sortAdapter{})); err != nil {
+		t.Fatal(err)
+	}
+
+	env := MakeSimpleEnv()
+	env.Funcs["Sprint"] = reflect.ValueOf(func(s fmt.Stringer) string { return s.String() })
+	env.Funcs["Sort"] = reflect.ValueOf(sort.Sort)
+	expectInterp(t, "type Celsius float64", env)
+	expectInterp(t, `func (c Celsius) String() string { return "C" }`, env)
+	expectResult(t, "Sprint(Celsius(1))", env, "C")
+	expectInterp(t, "var i interface{ String() string } = Celsius(2)", env)
+	expectResult(t, "Sprint(i)", env, "C")
+
+	expectInterp(t, "type Ints []int", env)
+	expectInterp(t, "func (s Ints) Len() int { return len(s) }", env)
+	expectInterp(t, "func (s Ints) Less(i, j int) bool { return s[i] < s[j] }", env)
+	expectInterp(t, "func (s Ints) Swap(i, j int) { x := s[i]; s[i] = s[j]; s[j] = x }", env)
+	expectInterp(t, "xs := Ints{3, 1, 2}", env)
+	expectInterp(t, "Sort(xs)", env)
+	expectResult(t, "[]int(xs)", env, []int{1, 2, 3})
+	expectCheckError(t, "Sort(Celsius(1))", env,
+		"cannot use Celsius(1) (type Celsius) as type sort.Interface in function argument")
+
+	// Adapted values are recovered by type assertions
+	env.Types["Stringer"] = stringerT
+	expectInterp(t, "var s Stringer = Celsius(3)", env)
+	expectResult(t, "float64(s.(Celsius))", env, 3.0)
+	expectResult(t, "s.(interface{ String() string }).String()", env, "C")
+	expectInterp(t, "type Fahr float64", env)
+	expectInterp(t, `func (f Fahr) String() string { return "F" }`, env)
+	expectInterp(t, "_, ok := s.(Fahr)", env)
+	expectResult(t, "ok", env, false)
+	expectInterp(t, "x := 0.0", env)
+	expectInterp(t, "switch v := s.(type) { case Celsius: x = float64(v) }", env)
+	expectResult(t, "x", env, 3.0)
+}
+
+func TestFuncCallEmptyInterfaceAdapter(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Funcs["Sprint"] = reflect.ValueOf(fmt.Sprint)
+	env.Funcs["Errorf"] = reflect.ValueOf(fmt.Errorf)
+	expectInterp(t, "type Celsius float64", env)
+	expectInterp(t, `func (c Celsius) String() string { return "C" }`, env)
+	expectInterp(t, "type Failure int", env)
+	expectInterp(t, `func (*Failure) Error() string { return "failed" }`, env)
+	expectResult(t, "Sprint(Celsius(1))", env, "C")
+	expectInterp(t, "f := Failure(1)", env)
+	expectResult(t, "Sprint(&f)", env, "failed")
+	expectInterp(t, "var i interface{} = Celsius(2)", env)
+	expectResult(t, "Sprint(i, 3)", env, "C 3")
+
+	// Adapted errors are recovered by type assertions
+	expectInterp(t, "var err error = &f", env)
+	expectResult(t, "err.Error()", env, "failed")
+	expectResult(t, "Errorf(\"%w\", err).Error()", env, "failed")
+	expectInterp(t, "_, ok := err.(*Failure)", env)
+	expectResult(t, "ok", env, true)
+}
+
+func TestRegisterAdapterErrors(t *testing.T) {
+	stringerT := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	if err := RegisterAdapter(reflect.TypeOf(0), reflect.TypeOf(stringerAdapter{})); err == nil {
+		t.Fatalf("Expected error registering adapter for int")
+	} else if err := RegisterAdapter(stringerT, reflect.TypeOf(sortAdapter{})); err == nil {
+		t.Fatalf("Expected error registering adapter not implementing fmt.Stringer")
+	} else if err := RegisterAdapter(stringerT, reflect.TypeOf(struct{ fmt.Stringer; StringFunc func() string }{})); err == nil {
+		t.Fatalf("Expected error registering adapter without field Adapted")
+	}
+}
@@ -7,7 +7,7 @@ import (
 )
 
 func evalCompositeLit(lit *CompositeLit, env Env) (reflect.Value, error) {
-	t := lit.KnownType()[0]
+	t := unhackType(lit.KnownType()[0])
 
 	switch t.Kind() {
 	case reflect.Map:
@@ -84,7 +84,7 @@ func evalCompositeLitStruct(t reflect.Type, lit *CompositeLit, env Env) (reflect
 		} else {
 			elt = lit.Elts[i]
 		}
-		field := v.Field(f)
+		field := fieldByIndex(v, []int{f})
 		if elem, err := evalTypedExpr(elt, knownType{field.Type()}, env); err != nil {
 			return reflect.Value{}, err
 		} else {
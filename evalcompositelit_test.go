@@ -116,3 +116,63 @@ func TestCompositeStructKeyValues(t *testing.T) {
 
 	expectResult(t, expr, env, expected)
 }
+
+func TestCompositeAnonStruct(t *testing.T) {
+	env := MakeSimpleEnv()
+
+	expected := []struct{ Name string; Age int }{{"a", 1}, {Age: 2}}
+	expr := `[]struct{ Name string; Age int }{{"a", 1}, {Age: 2}}`
+
+	expectResult(t, expr, env, expected)
+}
+
+func TestCompositeAnonStructTag(t *testing.T) {
+	env := MakeSimpleEnv()
+
+	expected := struct{ Name string `json:"name"` }{"a"}
+	expr := "struct{ Name string `json:\"name\"` }{\"a\"}"
+
+	expectResult(t, expr, env, expected)
+}
+
+func TestCompositeAnonStructEmbedded(t *testing.T) {
+	type Alice struct {
+		Bob int
+	}
+
+	env := MakeSimpleEnv()
+	env.Types["Alice"] = reflect.TypeOf(Alice{})
+
+	expected := struct{ Alice; Carol int }{Alice{1}, 2}
+	expr := "struct{ Alice; Carol int }{Alice{1}, 2}"
+
+	expectResult(t, expr, env, expected)
+}
+
+func TestCompositeArrayLen(t *testing.T) {
+	env := MakeSimpleEnv()
+
+	expected := [3]int{1, 2}
+	expr := "[3]int{1, 2}"
+
+	expectResult(t, expr, env, expected)
+}
+
+func TestCompositeArrayEllipsis(t *testing.T) {
+	env := MakeSimpleEnv()
+
+	expected := [...]string{"a", 4: "b"}
+	expr := `[...]string{"a", 4: "b"}`
+
+	expectResult(t, expr, env, expected)
+}
+
+func TestCompositeArrayConstLen(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Consts["N"] = reflect.ValueOf(NewConstInt64(2))
+
+	expected := [][2 * 2]bool{{true}}
+	expr := "[][N * 2]bool{{true}}"
+
+	expectResult(t, expr, env, expected)
+}
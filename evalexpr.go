@@ -31,6 +31,8 @@ func EvalExpr(expr Expr, env Env) ([]reflect.Value, error) {
 		return []reflect.Value{v}, err
 	case *IndexExpr:
 		return evalIndexExpr(node, env)
+	case *IndexListExpr:
+		return []reflect.Value{node.fun}, nil
 	case *SliceExpr:
 		v, err := evalSliceExpr(node, env)
 		return []reflect.Value{v}, err
@@ -1,13 +1,80 @@
 package eval
 
 import (
+	"hash/maphash"
 	"reflect"
+	"runtime"
+	"sync"
+	"weak"
 )
 
+// A funcFrame holds the state of a single call to a function literal.
+type funcFrame struct {
+	defers []deferredCall
+
+	// The panic being propagated, or nil if the function is returning
+	// normally or the panic has been recovered.
+	panicking error
+
+	// The frame which deferred this call, if the function was called
+	// directly as a deferred call. Only such calls may recover.
+	deferredBy *funcFrame
+}
+
+type deferredCall struct {
+	call *CallExpr
+	fun reflect.Value
+	args []reflect.Value
+
+	// Builtin calls are run in env
+	env Env
+}
+
+// frameEnv associates the scopes of a function body with its frame.
+type frameEnv struct {
+	Env
+	frame *funcFrame
+}
+
+func newFrameEnv(env Env, frame *funcFrame) Env {
+	if fenv, ok := env.(frameEnv); ok {
+		env = fenv.Env
+	}
+	return frameEnv{env, frame}
+}
+
+func (env frameEnv) PushScope() Env {
+	return frameEnv{env.Env.PushScope(), env.frame}
+}
+
+func (env frameEnv) PopScope() Env {
+	if parent := env.Env.PopScope(); parent != nil {
+		return frameEnv{parent, env.frame}
+	}
+	return nil
+}
+
+// Returns the frame of the innermost function literal executing in env,
+// or nil outside of function literals.
+func envFrame(env Env) *funcFrame {
+	if fenv, ok := env.(frameEnv); ok {
+		return fenv.frame
+	}
+	return nil
+}
+
 func evalFuncLit(lit *FuncLit, env Env) (reflect.Value, error) {
+	return makeFuncLit(lit, env), nil
+}
+
+func makeFuncLit(lit *FuncLit, env Env) reflect.Value {
 	ft := lit.Type.KnownType()[0]
-	env = env.PushScope()
-	v := reflect.MakeFunc(ft, func(in []reflect.Value) (out []reflect.Value) {
+	return newInterpFunc(ft, func(in []reflect.Value, deferredBy *funcFrame) []reflect.Value {
+		// Each call declares its params and results in a new scope, so
+		// that recursive and concurrent calls do not share them. Captured
+		// variables are shared through the enclosing scopes.
+		frame := &funcFrame{deferredBy: deferredBy}
+		env := newFrameEnv(env.PushScope(), frame)
 		i := 0
 		for _, field := range lit.Type.Params.List {
 			if field.Names != nil {
@@ -19,50 +86,200 @@ func evalFuncLit(lit *FuncLit, env Env) (reflect.Value, error) {
 					}
 					i += 1
 				}
+			} else {
+				i += 1
 			}
 		}
+
+		// Names of named results, "" for unnamed or blank results
+		resultNames := make([]string, ft.NumOut())
 		i = 0
 		if lit.Type.Results != nil {
 			for _, field := range lit.Type.Results.List {
 				for _, name := range field.Names {
 					if name.Name != "_" {
 						env.AddVar(name.Name, reflect.New(ft.Out(i)))
+						resultNames[i] = name.Name
 					}
 					i += 1
 				}
 			}
 		}
-		if last, err := InterpStmt(lit.Body, env); err != nil {
-			panic(err)
-		} else if ft.NumOut() == 0 {
-			// void func which may terminate on a non-ReturnStmt
-		} else if ret := last.Last.(*ReturnStmt); len(ret.Results) == ft.NumOut() {
-			for i, result := range ret.Results {
-				if r, err := evalTypedExpr(result, knownType{ft.Out(i)}, last.Env); err != nil {
-					panic(err)
-				} else {
-					out = append(out, r[0])
+
+		out, err := interpFuncBody(lit, ft, env)
+		if err == nil {
+			// Results may refer to the named result variables
+			for i, v := range out {
+				out[i] = reflect.New(ft.Out(i)).Elem()
+				out[i].Set(v)
+			}
+			for i, name := range resultNames {
+				if name != "" {
+					env.Var(name).Elem().Set(out[i])
 				}
 			}
-		} else if len(ret.Results) == 1 {
-			// return multi()
-			if rs, err := EvalExpr(ret.Results[0], env); err != nil {
-				panic(err)
+		}
+		frame.panicking = err
+		frame.runDefers()
+		if frame.panicking != nil {
+			panic(frame.panicking)
+		}
+
+		// Deferred calls may have modified named results, and a recovered
+		// function returns its current results.
+		if out == nil {
+			out = make([]reflect.Value, ft.NumOut())
+			for i := range out {
+				out[i] = reflect.Zero(ft.Out(i))
+			}
+		}
+		for i, name := range resultNames {
+			if name != "" {
+				out[i] = env.Var(name).Elem()
+			}
+		}
+		return out
+	})
+}
+
+// Interpret the body of lit, returning the function's results. Panics of
+// calls within the body are returned as errors.
+func interpFuncBody(lit *FuncLit, ft reflect.Type, env Env) (out []reflect.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out, err = nil, panicError(r)
+		}
+	}()
+
+	last, err := InterpStmt(lit.Body, env)
+	if err != nil {
+		return nil, err
+	} else if ft.NumOut() == 0 {
+		// void func which may terminate on a non-ReturnStmt
+		return nil, nil
+	}
+	ret := last.Last.(*ReturnStmt)
+	if len(ret.Results) == ft.NumOut() {
+		for i, result := range ret.Results {
+			if r, err := evalTypedExpr(result, knownType{ft.Out(i)}, last.Env); err != nil {
+				return nil, err
 			} else {
-				out = rs
+				out = append(out, r[0])
 			}
-		} else {
-			for _, field := range lit.Type.Results.List {
-				for _, name := range field.Names {
-					if name.Name != "_" {
-						out = append(out, env.Var(name.Name).Elem())
-					} else {
-						out = append(out, reflect.Zero(field.KnownType()[0]))
-					}
+		}
+	} else if len(ret.Results) == 1 {
+		// return multi()
+		if out, err = EvalExpr(ret.Results[0], last.Env); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, field := range lit.Type.Results.List {
+			for _, name := range field.Names {
+				if name.Name != "_" {
+					out = append(out, env.Var(name.Name).Elem())
+				} else {
+					out = append(out, reflect.Zero(field.KnownType()[0]))
 				}
 			}
 		}
+	}
+	return out, nil
+}
+
+// Run deferred calls in LIFO order. A panic in a deferred call replaces
+// the panic being propagated.
+func (frame *funcFrame) runDefers() {
+	for len(frame.defers) != 0 {
+		n := len(frame.defers) - 1
+		d := frame.defers[n]
+		frame.defers = frame.defers[:n]
+		if err := d.run(frame); err != nil {
+			frame.panicking = err
+		}
+	}
+}
+
+func (d deferredCall) run(frame *funcFrame) error {
+	if d.call.isBuiltin {
+		return callBuiltinStmt(d.call, d.args, d.env)
+	}
+	_, err := callFun(d.call, d.fun, d.args, frame)
+	return err
+}
+
+// An interpFunc is the implementation of a func value created by the
+// interpreter, such as a function literal or method value. call receives
+// the arguments as passed by reflect.MakeFunc, and the frame which deferred
+// the call, or nil if the func was not called directly as a deferred call.
+type interpFunc struct {
+	fun reflect.Value
+	call func(in []reflect.Value, deferredBy *funcFrame) []reflect.Value
+}
+
+// Registry of interpFuncs keyed by the hash of their func value. Entries
+// are weak and removed once the func is collected. A hash collision only
+// loses the registration of the older func, which is then called as a host
+// func.
+var interpFuncs struct {
+	sync.Map // map[uint64]weak.Pointer[interpFunc]
+	seed maphash.Seed
+}
+
+func init() {
+	interpFuncs.seed = maphash.MakeSeed()
+}
+
+// Returns a func value of type ft implemented by call, registered so that
+// the interpreter calls the implementation directly. Host callers are
+// returned the plain values of Named types.
+func newInterpFunc(ft reflect.Type, call func([]reflect.Value, *funcFrame) []reflect.Value) reflect.Value {
+	f := &interpFunc{call: call}
+	f.fun = reflect.MakeFunc(ft, func(in []reflect.Value) []reflect.Value {
+		out := f.call(in, nil)
+		for i, v := range out {
+			out[i] = hostValue(v, ft.Out(i))
+		}
 		return out
 	})
-	return v, nil
+	h := maphash.Comparable(interpFuncs.seed, funcKey(f.fun))
+	wp := weak.Make(f)
+	interpFuncs.Store(h, wp)
+	runtime.AddCleanup(f, func(h uint64) {
+		interpFuncs.CompareAndDelete(h, wp)
+	}, h)
+	return f.fun
+}
+
+// Returns the interpFunc implementing fun, or nil if fun is a host func.
+func lookupInterpFunc(fun reflect.Value) *interpFunc {
+	if fun.Kind() != reflect.Func || fun.IsNil() || !fun.CanInterface() {
+		return nil
+	}
+	key := funcKey(fun)
+	wp, ok := interpFuncs.Load(maphash.Comparable(interpFuncs.seed, key))
+	if !ok {
+		return nil
+	} else if f := wp.(weak.Pointer[interpFunc]).Value(); f != nil && funcKey(f.fun) == key {
+		return f
+	}
+	return nil
+}
+
+// Values of the same func compare equal once their flags are discarded.
+func funcKey(fun reflect.Value) reflect.Value {
+	return reflect.ValueOf(fun.Interface())
+}
+
+// Call fun with in as passed by reflect.MakeFunc, for funcs which wrap
+// another func, such as method values. The deferring frame is forwarded
+// if fun is interpreted.
+func forwardCall(fun reflect.Value, in []reflect.Value, deferredBy *funcFrame) []reflect.Value {
+	if f := lookupInterpFunc(fun); f != nil {
+		return f.call(in, deferredBy)
+	}
+	in = hostArgs(fun.Type(), in, true)
+	if fun.Type().IsVariadic() {
+		return fun.CallSlice(in)
+	}
+	return fun.Call(in)
 }
@@ -14,7 +14,7 @@ func evalIdent(ident *Ident, env Env) (reflect.Value, error) {
 	case envVar:
 		for searchEnv := env; searchEnv != nil; searchEnv = searchEnv.PopScope() {
 			if v := searchEnv.Var(name); v.IsValid() {
-				return v.Elem(), nil
+				return varValue(v), nil
 			}
 		}
 	case envFunc:
@@ -26,6 +26,9 @@ func evalIndexExpr(index *IndexExpr, env Env) ([]reflect.Value, error) {
 		return []reflect.Value{v, reflect.ValueOf(ok)}, nil
 	case reflect.Ptr:
 		// Short hand for array pointers
+		if x.IsNil() {
+			return []reflect.Value{}, PanicInvalidDereference{}
+		}
 		x = x.Elem()
 		fallthrough
 	default:
@@ -0,0 +1,98 @@
+package eval
+
+import (
+	"reflect"
+	"testing"
+)
+
+func genericContains[S ~[]E, E comparable](s S, e E) bool {
+	for _, x := range s {
+		if x == e {
+			return true
+		}
+	}
+	return false
+}
+
+func genericMax[T int | float64](x T, ys ...T) T {
+	for _, y := range ys {
+		if y > x {
+			x = y
+		}
+	}
+	return x
+}
+
+type genericInts []int
+
+type genericPair[K comparable, V any] struct {
+	Key K
+	Value V
+}
+
+func makeGenericEnv() *SimpleEnv {
+	env := MakeSimpleEnv()
+	intsT, stringsT := reflect.TypeOf([]int{}), reflect.TypeOf([]string{})
+	intT, stringT, floatT := reflect.TypeOf(0), reflect.TypeOf(""), reflect.TypeOf(0.0)
+
+	AddGenericFunc(env, "Contains", []reflect.Type{intsT, intT}, reflect.ValueOf(genericContains[[]int, int]))
+	AddGenericFunc(env, "Contains", []reflect.Type{stringsT, stringT}, reflect.ValueOf(genericContains[[]string, string]))
+	AddGenericFunc(env, "Max", []reflect.Type{intT}, reflect.ValueOf(genericMax[int]))
+	AddGenericFunc(env, "Max", []reflect.Type{floatT}, reflect.ValueOf(genericMax[float64]))
+	AddGenericType(env, "Pair", []reflect.Type{stringT, intT}, reflect.TypeOf(genericPair[string, int]{}))
+
+	pkg := MakeSimpleEnv()
+	AddGenericFunc(pkg, "Contains", []reflect.Type{intsT, intT}, reflect.ValueOf(genericContains[[]int, int]))
+	AddGenericType(pkg, "Pair", []reflect.Type{stringT, intT}, reflect.TypeOf(genericPair[string, int]{}))
+	env.Pkgs["slices"] = pkg
+	return env
+}
+
+func TestGenericFuncInstantiation(t *testing.T) {
+	env := makeGenericEnv()
+	expectResult(t, "Contains[[]int, int]([]int{1, 2}, 2)", env, true)
+	expectResult(t, "Contains[[]string, string]([]string{\"a\"}, \"b\")", env, false)
+	expectResult(t, "Contains[[]int]([]int{1, 2}, 3)", env, false)
+	expectResult(t, "Max[float64](1, 2.5)", env, 2.5)
+	expectResult(t, "slices.Contains[[]int, int]([]int{1}, 1)", env, true)
+	expectType(t, "Max[int]", env, reflect.TypeOf(genericMax[int]))
+}
+
+func TestGenericFuncInference(t *testing.T) {
+	env := makeGenericEnv()
+	expectResult(t, "Contains([]int{1, 2}, 2)", env, true)
+	expectResult(t, "Contains([]string{\"a\"}, \"a\")", env, true)
+	expectResult(t, "slices.Contains([]int{1}, 2)", env, false)
+	expectResult(t, "Max(1, 2, 3)", env, 3)
+	expectResult(t, "Max(1.5, 0.5)", env, 1.5)
+	expectResult(t, "Max(0.5, []float64{1, 2}...)", env, 2.0)
+	// 2.5 is truncated by int, so only the float64 instantiation matches
+	expectResult(t, "Max(1, 2.5)", env, 2.5)
+}
+
+func TestGenericTypeInstantiation(t *testing.T) {
+	env := makeGenericEnv()
+	expectResult(t, "Pair[string, int]{\"a\", 1}", env, genericPair[string, int]{"a", 1})
+	expectResult(t, "slices.Pair[string, int]{Value: 2}.Value", env, 2)
+	expectType(t, "[]Pair[string, int]{}", env, reflect.TypeOf([]genericPair[string, int]{}))
+
+	scope := env.PushScope()
+	expectInterp(t, "var p Pair[string, int]; p.Key = \"k\"", scope)
+}
+
+func TestGenericErrors(t *testing.T) {
+	env := makeGenericEnv()
+	env.Vars["x"] = reflect.ValueOf(new(int))
+
+	expectCheckError(t, "Contains", env, "cannot use generic function Contains without instantiation")
+	expectCheckError(t, "Max[int, int](1)", env, "got 2 type arguments but Max has 1 type parameters")
+	expectCheckError(t, "Contains[[]bool, bool]", env, "Contains[[]bool, bool] is not instantiated by the host")
+	expectCheckError(t, "Contains[1]", env, "1 is not a type")
+	expectCheckError(t, "Contains([]bool{}, true)", env, "cannot infer type arguments of Contains")
+	AddGenericFunc(env, "Contains", []reflect.Type{reflect.TypeOf(genericInts{}), reflect.TypeOf(0)}, reflect.ValueOf(genericContains[genericInts, int]))
+	expectCheckError(t, "Contains(nil, 1)", env, "cannot infer type arguments of Contains: more than one instantiation matches")
+	expectCheckError(t, "x[int, string]", env, "invalid operation: x is not a generic function or type")
+	expectCheckError(t, "Pair{}", env, "cannot use generic type Pair without instantiation")
+	expectCheckError(t, "Pair[int, int]{}", env, "Pair[int, int] is not instantiated by the host")
+	expectCheckError(t, "Pair[string]{}", env, "got 1 type arguments but Pair has 2 type parameters")
+}
@@ -11,6 +11,16 @@ func evalSelectorExpr(selector *SelectorExpr, env Env) (reflect.Value, error) {
 		return vs, err
 	}
 
+	if selector.isMethodExpr {
+		t := selector.X.KnownType()[0]
+		if m := selector.namedMethod; m != nil {
+			return m.expr(t), nil
+		} else if t.Kind() == reflect.Interface {
+			return interfaceMethodExpr(selector.KnownType()[0], selector.Sel.Name), nil
+		}
+		return unhackType(t).Method(selector.method).Func, nil
+	}
+
 	vs, err := EvalExpr(selector.X, env)
 	if err != nil {
 		return reflect.Value{}, err
@@ -21,12 +31,46 @@ func evalSelectorExpr(selector *SelectorExpr, env Env) (reflect.Value, error) {
 		if t.Kind() == reflect.Ptr {
 			v = v.Elem()
 		}
-		return v.FieldByIndex(selector.field), nil
+		return fieldByIndex(v, selector.field), nil
 	}
 
+	if _, ok := selector.X.KnownType()[0].(*Interface); ok {
+		// Values of virtual interfaces are stored as interface{}
+		if v.IsNil() {
+			return reflect.Value{}, PanicInvalidDereference{}
+		}
+		return interfaceMethod(v, selector.Sel.Name), nil
+	}
+	if m := selector.namedMethod; m != nil {
+		if !m.ptrRecv && v.Kind() == reflect.Ptr && v.IsNil() {
+			return reflect.Value{}, PanicInvalidDereference{}
+		}
+		return m.bind(v), nil
+	}
 	if selector.isPtrReceiver {
 		v = v.Addr()
 	}
 	return v.Method(selector.method), nil
 }
 
+
+// Returns method name of the dynamic value of the non-nil interface value v.
+func interfaceMethod(v reflect.Value, name string) reflect.Value {
+	dynamic, dynamicT := dynamicValue(v)
+	if named, ok := dynamicT.(*Named); ok {
+		return named.lookupMethod(name).bind(dynamic)
+	}
+	return dynamic.MethodByName(name)
+}
+
+// Returns the method expression of method name of an interface type, where
+// ft is the type of the method expression.
+func interfaceMethodExpr(ft reflect.Type, name string) reflect.Value {
+	return newInterpFunc(ft, func(in []reflect.Value, deferredBy *funcFrame) []reflect.Value {
+		if in[0].IsNil() {
+			panic(PanicInvalidDereference{})
+		}
+		method := interfaceMethod(in[0], name)
+		return forwardCall(method, in[1:], deferredBy)
+	})
+}
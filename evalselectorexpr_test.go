@@ -1,6 +1,7 @@
 package eval
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
 	"testing"
@@ -162,3 +163,64 @@ func TestSelectPackageFunc(t *testing.T) {
 	expectResult(t, `fmt.Sprintf("abc")`, env, fmt.Sprintf("abc"))
 }
 
+
+func TestSelectAnonStructField(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectResult(t, "struct{ A, B int }{1, 2}.B", env, 2)
+}
+
+func TestSelectAnonStructUnexportedField(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectResult(t, "struct{ a int }{1}.a", env, 1)
+	expectResult(t, "(&struct{ a int }{2}).a", env, 2)
+}
+
+func TestSelectAnonStructPromotedField(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Types["SelNested"] = reflect.TypeOf(SelNested{})
+	expectResult(t, "struct{ SelNested }{SelNested{3}}.D", env, 3)
+	expectResult(t, "struct{ *SelNested }{&SelNested{4}}.D", env, 4)
+}
+
+func TestSelectMethodExpr(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Types["SelStruct"] = reflect.TypeOf(SelStruct{})
+	env.Types["SelInt"] = reflect.TypeOf(SelInt(0))
+	expectResult(t, "SelStruct.E(SelStruct{})", env, 1)
+	expectResult(t, "(*SelStruct).E(&SelStruct{})", env, 1)
+	expectResult(t, "(*SelStruct).F(&SelStruct{})", env, 2)
+	expectInterp(t, "f := SelInt.E", env)
+	expectResult(t, "f(SelInt(3))", env, 1)
+	expectType(t, "(*SelInt).F", env, reflect.TypeOf((*SelInt).F))
+}
+
+func TestSelectMethodExprPkg(t *testing.T) {
+	env := MakeSimpleEnv()
+	pkg := MakeSimpleEnv()
+	env.Pkgs["bytes"] = pkg
+	pkg.Types["Buffer"] = reflect.TypeOf(bytes.Buffer{})
+	expectInterp(t, "var b bytes.Buffer", env)
+	expectInterp(t, `(*bytes.Buffer).WriteString(&b, "abc")`, env)
+	expectResult(t, "(*bytes.Buffer).Len(&b)", env, 3)
+}
+
+func TestSelectMethodExprInterface(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Types["SelInterface"] = reflect.TypeOf((*SelInterface)(nil)).Elem()
+	var i SelInterface = new(SelInt)
+	env.Vars["i"] = reflect.ValueOf(&i)
+	expectResult(t, "SelInterface.F(i)", env, 2)
+	expectResult(t, "interface{ E() int }.E(i)", env, 1)
+	expectPanic(t, "SelInterface.E(nil)", env, "runtime error: invalid memory address or nil pointer dereference")
+}
+
+func TestSelectMethodExprErrors(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Types["SelStruct"] = reflect.TypeOf(SelStruct{})
+	expectCheckError(t, "SelStruct.F", env,
+		"invalid method expression SelStruct.F (needs pointer receiver: (*SelStruct).F)")
+	expectCheckError(t, "SelStruct.G", env, "SelStruct.G undefined (type eval.SelStruct has no method G)")
+	expectInterp(t, "type T int", env)
+	expectInterp(t, "func (*T) M() {}", env)
+	expectCheckError(t, "T.M", env, "invalid method expression T.M (needs pointer receiver: (*T).M)")
+}
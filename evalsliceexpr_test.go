@@ -58,3 +58,20 @@ func TestSliceString(t *testing.T) {
 
 }
 
+
+func TestSliceArrayPtr(t *testing.T) {
+	a := &[3]int{1, 2, 3}
+
+	env := MakeSimpleEnv()
+	env.Vars["a"] = reflect.ValueOf(&a)
+
+	expected := a[1:]
+	expr := "a[1:]"
+
+	expectResult(t, expr, env, expected)
+
+	expected = a[:3]
+	expr = "a[:3]"
+
+	expectResult(t, expr, env, expected)
+}
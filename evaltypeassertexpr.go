@@ -16,20 +16,20 @@ func evalTypeAssertExpr(assert *TypeAssertExpr, env Env) ([]reflect.Value, error
 			vs := []reflect.Value{hackedNew(aT).Elem(), reflect.ValueOf(false)}
 			return vs, PanicInterfaceConversion{aT: aT}
 		}
-		dynamic := v.Elem()
-		dT := dynamic.Type()
+		dynamic, dT := dynamicValue(v)
 		if aT.Kind() == reflect.Interface {
-			if !dT.Implements(aT) {
+			if !implements(dT, aT) {
 				vs := []reflect.Value{hackedNew(aT).Elem(), reflect.ValueOf(false)}
 				return vs, PanicInterfaceConversion{xT, aT, nil}
 			}
+			dynamic = interfaceValue(dynamic, dT, aT)
 		} else {
 			if dT != aT {
 				vs := []reflect.Value{hackedNew(aT).Elem(), reflect.ValueOf(false)}
 				return vs, PanicInterfaceConversion{xT, aT, dT}
 			}
 		}
-		r := reflect.New(aT).Elem()
+		r := hackedNew(aT).Elem()
 		r.Set(dynamic)
 		return []reflect.Value{r, reflect.ValueOf(true)}, nil
 	}
@@ -1,6 +1,7 @@
 package eval
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -95,3 +96,44 @@ func makeTypeAssertEnv() *SimpleEnv {
 
 	return env
 }
+
+type typeAssertLen []int
+
+func (l typeAssertLen) Len() int { return len(l) }
+
+func TestTypeAssertInterfaceLit(t *testing.T) {
+	env := makeTypeAssertEnv()
+	env.Types["L"] = reflect.TypeOf(typeAssertLen{})
+	a := interface{}(typeAssertLen{1, 2})
+	env.Vars["a"] = reflect.ValueOf(&a)
+	expectResult(t, "a.(interface{ Len() int }).Len()", env, 2)
+	expectPanic(t, "a.(interface{ Len() string })", env,
+		"interface conversion: interface {} is not interface { Len() string }: missing method Len")
+	expectResult(t, "interface{ Len() int }(L{1}).Len()", env, 1)
+}
+
+type typeAssertLener interface {
+	Len() int
+}
+
+func TestTypeAssertInterfaceLitToHost(t *testing.T) {
+	env := makeTypeAssertEnv()
+	env.Types["Lener"] = reflect.TypeOf(new(typeAssertLener)).Elem()
+	a := interface{}(typeAssertLen{1})
+	env.Vars["a"] = reflect.ValueOf(&a)
+	expectResults(t, "a.(interface{ Len() int }).(Lener)", env, typeAssertLener(typeAssertLen{1}), true)
+	expectInterp(t, "var l Lener = a.(interface{ Len() int })", env)
+	expectResult(t, "l.Len()", env, 1)
+}
+
+func TestTypeAssertError(t *testing.T) {
+	env := MakeSimpleEnv()
+	var i interface{} = errors.New("boom")
+	env.Vars["i"] = reflect.ValueOf(&i)
+	expectResult(t, "i.(error).Error()", env, "boom")
+	expectInterp(t, "e, ok := i.(error)", env)
+	expectResult(t, "ok", env, true)
+	env.Vars["l"] = reflect.ValueOf(new(typeAssertLener))
+	expectInterp(t, "_, ok = l.(error)", env)
+	expectResult(t, "ok", env, false)
+}
@@ -6,6 +6,11 @@ import (
 	"go/token"
 )
 
+// NonBlockingRecv makes receive expressions return immediately rather than
+// block. Receiving from an empty channel then yields the zero value, and
+// false in the v, ok form.
+var NonBlockingRecv = false
+
 func evalUnaryExpr(unary *UnaryExpr, env Env) ([]reflect.Value, error) {
 	if unary.IsConst() {
 		return []reflect.Value{unary.Const()}, nil
@@ -21,8 +26,13 @@ func evalUnaryExpr(unary *UnaryExpr, env Env) ([]reflect.Value, error) {
 	if unary.Op == token.AND {
 		return []reflect.Value{x.Addr()}, nil
 	} else if unary.Op == token.ARROW {
-		// TODO[crc] use x.Recv in contexts where blocking is acceptable
-		v, ok := x.TryRecv()
+		var v reflect.Value
+		var ok bool
+		if NonBlockingRecv {
+			v, ok = x.TryRecv()
+		} else {
+			v, ok = x.Recv()
+		}
 		if !v.IsValid() {
 			v = reflect.New(x.Type().Elem()).Elem()
 		}
@@ -34,7 +44,7 @@ func evalUnaryExpr(unary *UnaryExpr, env Env) ([]reflect.Value, error) {
 	switch x.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		r, err = evalUnaryIntExpr(x, unary.Op)
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 		r, err = evalUnaryUintExpr(x, unary.Op)
 	case reflect.Float32, reflect.Float64:
 		r, err = evalUnaryFloatExpr(x, unary.Op)
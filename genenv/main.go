@@ -0,0 +1,477 @@
+// Command genenv generates Go source declaring an eval.SimpleEnv for each
+// of the packages named by its import path arguments. Every exported func,
+// type, var and constant of a package is added to its Env. Vars are added
+// as pointers, and untyped numeric constants as exact *eval.ConstNumbers,
+// so that constants such as math.Pi or math.MaxUint64 remain untyped when
+// evaluated. Generic funcs and types are skipped, as they must be
+// instantiated before they can be used.
+//
+// Packages are type checked from source with go/types, so no compiled
+// packages or network access are required. A typical use is
+//
+//  //go:generate go run github.com/0xfaded/eval/genenv -o math_env.go math
+//
+// which declares func MathEnv() *eval.SimpleEnv and, unless -register=false
+// is given, registers its result with eval.DefaultRegistry in an init func.
+//
+// Declarations added to the standard library after the Go version given by
+// -go, according to the API files of $GOROOT/api, are written to separate
+// files with build constraints, such as math_env_go121.go for go1.21. The
+// generated source then builds with every Go version from -go onwards.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/constant"
+	"go/format"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var (
+	output = flag.String("o", "", "output file, or standard output if empty")
+	pkgName = flag.String("pkg", os.Getenv("GOPACKAGE"), "package name of the output file, main if empty")
+	funcName = flag.String("func", "", "name of the generated func, <Name>Env if empty; only valid for a single package")
+	register = flag.Bool("register", true, "register the generated Envs with eval.DefaultRegistry")
+	goVersion = flag.String("go", "", "oldest supported Go version, such as go1.18; newer declarations are gated by build constraints")
+	apiDir = flag.String("api", filepath.Join(runtime.GOROOT(), "api"), "directory of the Go API files used by -go")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: genenv [flags] importpath...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 || *funcName != "" && flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	g := &Generator{Package: *pkgName, Register: *register}
+	if g.Package == "" {
+		g.Package = "main"
+	}
+	if *goVersion != "" {
+		minor, ok := parseGoVersion(*goVersion)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "genenv: invalid Go version %q\n", *goVersion)
+			os.Exit(2)
+		} else if *output == "" {
+			fmt.Fprintf(os.Stderr, "genenv: -go requires -o\n")
+			os.Exit(2)
+		}
+		api, err := ReadAPI(*apiDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "genenv: %v\n", err)
+			os.Exit(1)
+		}
+		g.API = api
+		g.MinVersion = minor
+	}
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+	for _, path := range flag.Args() {
+		pkg, err := imp.Import(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "genenv: %v\n", err)
+			os.Exit(1)
+		}
+		g.Add(pkg, *funcName)
+	}
+
+	src, err := g.Source()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "genenv: %v\n", err)
+		os.Exit(1)
+	}
+	if *output == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*output, src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "genenv: %v\n", err)
+		os.Exit(1)
+	}
+	for _, minor := range g.Versions() {
+		src, err := g.VersionSource(minor)
+		if err == nil {
+			filename := fmt.Sprintf("%s_go1%d.go", strings.TrimSuffix(*output, ".go"), minor)
+			err = os.WriteFile(filename, src, 0644)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "genenv: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// A Generator accumulates the Env declarations of type checked packages.
+type Generator struct {
+	// Package name of the generated file
+	Package string
+
+	// If true, each Env is registered with eval.DefaultRegistry
+	Register bool
+
+	// The minor Go version in which each declaration of the standard
+	// library was added, keyed by import path and name such as strings.Cut,
+	// as returned by ReadAPI. Declarations added after go1.MinVersion are
+	// excluded from Source and written by VersionSource instead.
+	API map[string]int
+	MinVersion int
+
+	pkgs []*types.Package
+	funcs []string
+	aliases []string
+}
+
+// Add a declaration of func name returning the Env of pkg. If name is
+// empty, the func is named after the package, such as MathEnv.
+func (g *Generator) Add(pkg *types.Package, name string) {
+	if name == "" {
+		name = envFuncName(pkg.Name())
+	}
+	alias := pkg.Name() + "pkg"
+	for _, other := range g.aliases {
+		if other == alias {
+			alias = fmt.Sprintf("%spkg%d", pkg.Name(), len(g.aliases))
+		}
+	}
+	g.pkgs = append(g.pkgs, pkg)
+	g.funcs = append(g.funcs, name)
+	g.aliases = append(g.aliases, alias)
+}
+
+// Returns the formatted source of the generated file.
+func (g *Generator) Source() ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by genenv; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", g.Package)
+	g.writeImports(&buf, g.pkgs)
+
+	if g.Register {
+		fmt.Fprintf(&buf, "\nfunc init() {\n")
+		for i, pkg := range g.pkgs {
+			fmt.Fprintf(&buf, "\teval.RegisterPackage(%q, %s())\n", pkg.Path(), g.funcs[i])
+		}
+		fmt.Fprintf(&buf, "}\n")
+	}
+	for i, pkg := range g.pkgs {
+		if err := g.writeEnvFunc(&buf, pkg, g.funcs[i], g.aliases[i]); err != nil {
+			return nil, err
+		}
+	}
+	return format.Source(buf.Bytes())
+}
+
+// Returns the minor Go versions after MinVersion in which declarations of
+// the added packages were introduced, in increasing order.
+func (g *Generator) Versions() []int {
+	seen := map[int]bool{}
+	var minors []int
+	for _, pkg := range g.pkgs {
+		for _, minor := range g.pkgVersions(pkg) {
+			if !seen[minor] {
+				seen[minor] = true
+				minors = append(minors, minor)
+			}
+		}
+	}
+	sort.Ints(minors)
+	return minors
+}
+
+// Returns the formatted source of the file declaring the declarations
+// introduced in go1.minor, constrained to build with go1.minor or later.
+// Each Env func applies these declarations before returning.
+func (g *Generator) VersionSource(minor int) ([]byte, error) {
+	var pkgs []*types.Package
+	var funcs, aliases []string
+	for i, pkg := range g.pkgs {
+		for _, m := range g.pkgVersions(pkg) {
+			if m == minor {
+				pkgs = append(pkgs, pkg)
+				funcs = append(funcs, g.funcs[i])
+				aliases = append(aliases, g.aliases[i])
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by genenv; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "//go:build go1.%d\n\n", minor)
+	fmt.Fprintf(&buf, "package %s\n\n", g.Package)
+	g.writeImports(&buf, pkgs)
+	for i, pkg := range pkgs {
+		fmt.Fprintf(&buf, "\n// Declarations of package %s added in go1.%d.\n", pkg.Path(), minor)
+		fmt.Fprintf(&buf, "var _ = func() bool {\n")
+		fmt.Fprintf(&buf, "\t%s = append(%[1]s, func(env *eval.SimpleEnv) {\n", versionsVar(funcs[i]))
+		if err := g.writeDecls(&buf, pkg, aliases[i], minor); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "\t})\n\treturn true\n}()\n")
+	}
+	return format.Source(buf.Bytes())
+}
+
+func (g *Generator) writeImports(w io.Writer, pkgs []*types.Package) {
+	fmt.Fprintf(w, "import (\n\t\"reflect\"\n\n\t\"github.com/0xfaded/eval\"\n")
+	for _, pkg := range pkgs {
+		for i := range g.pkgs {
+			if g.pkgs[i] == pkg {
+				fmt.Fprintf(w, "\t%s %q\n", g.aliases[i], pkg.Path())
+			}
+		}
+	}
+	fmt.Fprintf(w, ")\n")
+}
+
+func (g *Generator) writeEnvFunc(w io.Writer, pkg *types.Package, name, alias string) error {
+	versioned := len(g.pkgVersions(pkg)) != 0
+	if versioned {
+		fmt.Fprintf(w, "\n// Funcs adding the declarations of package %s introduced after go1.%d,\n", pkg.Path(), g.MinVersion)
+		fmt.Fprintf(w, "// appended by files with build constraints.\n")
+		fmt.Fprintf(w, "var %s []func(*eval.SimpleEnv)\n", versionsVar(name))
+	}
+	fmt.Fprintf(w, "\n// %s returns an Env holding the exported declarations of package %s.\n", name, pkg.Path())
+	fmt.Fprintf(w, "func %s() *eval.SimpleEnv {\n", name)
+	fmt.Fprintf(w, "\tenv := eval.MakeSimpleEnv()\n")
+	fmt.Fprintf(w, "\tenv.Path = %q\n", pkg.Path())
+	if err := g.writeDecls(w, pkg, alias, 0); err != nil {
+		return err
+	}
+	if versioned {
+		fmt.Fprintf(w, "\tfor _, add := range %s {\n\t\tadd(env)\n\t}\n", versionsVar(name))
+	}
+	fmt.Fprintf(w, "\treturn env\n}\n")
+	return nil
+}
+
+// Write the declarations of pkg introduced in go1.minor, or if minor is
+// zero, those available in go1.MinVersion.
+func (g *Generator) writeDecls(w io.Writer, pkg *types.Package, alias string, minor int) error {
+	scope := pkg.Scope()
+	for _, ident := range scope.Names() {
+		obj := scope.Lookup(ident)
+		if !obj.Exported() || skipped(obj) || g.version(pkg, ident) != minor {
+			continue
+		}
+		qualified := alias + "." + ident
+		switch obj := obj.(type) {
+		case *types.Const:
+			value, err := constValue(obj, qualified)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "\tenv.Consts[%q] = reflect.ValueOf(%s)\n", ident, value)
+		case *types.Var:
+			fmt.Fprintf(w, "\tenv.Vars[%q] = reflect.ValueOf(&%s)\n", ident, qualified)
+		case *types.Func:
+			fmt.Fprintf(w, "\tenv.Funcs[%q] = reflect.ValueOf(%s)\n", ident, qualified)
+		case *types.TypeName:
+			fmt.Fprintf(w, "\tenv.Types[%q] = reflect.TypeOf((*%s)(nil)).Elem()\n", ident, qualified)
+		}
+	}
+	return nil
+}
+
+// Returns the minor Go version introducing the declaration name of pkg if
+// it is after MinVersion, otherwise zero.
+func (g *Generator) version(pkg *types.Package, name string) int {
+	if minor := g.API[pkg.Path() + "." + name]; minor > g.MinVersion {
+		return minor
+	}
+	return 0
+}
+
+// Returns the minor Go versions after MinVersion introducing declarations
+// of pkg which are written to the generated source.
+func (g *Generator) pkgVersions(pkg *types.Package) []int {
+	seen := map[int]bool{}
+	var minors []int
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if !obj.Exported() || skipped(obj) {
+			continue
+		}
+		if minor := g.version(pkg, name); minor != 0 && !seen[minor] {
+			seen[minor] = true
+			minors = append(minors, minor)
+		}
+	}
+	return minors
+}
+
+// Reports whether obj is omitted from the generated source.
+func skipped(obj types.Object) bool {
+	switch obj := obj.(type) {
+	case *types.Func:
+		return obj.Type().(*types.Signature).TypeParams().Len() != 0
+	case *types.TypeName:
+		return hasTypeParams(obj.Type())
+	}
+	return false
+}
+
+// Returns the name of the var holding the versioned declarations applied
+// by the Env func name.
+func versionsVar(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes) + "Versions"
+}
+
+// ReadAPI reads the go1*.txt files of dir, usually $GOROOT/api, returning
+// the minor Go version in which each declaration was introduced. Keys are
+// the import path and name of the declaration, such as strings.Cut.
+func ReadAPI(dir string) (map[string]int, error) {
+	filenames, err := filepath.Glob(filepath.Join(dir, "go1*.txt"))
+	if err != nil {
+		return nil, err
+	} else if len(filenames) == 0 {
+		return nil, fmt.Errorf("no API files in %s", dir)
+	}
+	api := map[string]int{}
+	for _, filename := range filenames {
+		minor, ok := parseGoVersion(strings.TrimSuffix(filepath.Base(filename), ".txt"))
+		if !ok {
+			continue
+		}
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if key, ok := apiKey(line); ok {
+				if prev, ok := api[key]; !ok || minor < prev {
+					api[key] = minor
+				}
+			}
+		}
+	}
+	return api, nil
+}
+
+// Returns the key of the package level declaration of an API file line,
+// such as strings.Cut for "pkg strings, func Cut(string, string) ...".
+// Platform specific declarations are included; methods and fields are not.
+func apiKey(line string) (string, bool) {
+	if !strings.HasPrefix(line, "pkg ") {
+		return "", false
+	}
+	comma := strings.Index(line, ", ")
+	if comma < 0 {
+		return "", false
+	}
+	path := strings.Fields(line[len("pkg "):comma])[0]
+	fields := strings.Fields(line[comma + 2:])
+	if len(fields) < 2 {
+		return "", false
+	}
+	switch fields[0] {
+	case "const", "func", "type", "var":
+	default:
+		return "", false
+	}
+	name := fields[1]
+	if i := strings.IndexAny(name, "[(,"); i >= 0 {
+		name = name[:i]
+	}
+	if fields[0] == "type" && len(fields) > 2 && strings.HasSuffix(fields[2], ",") {
+		// A field or method of a struct or interface type
+		return "", false
+	}
+	return path + "." + name, true
+}
+
+// Parses a Go version such as go1.21 or go1.21.3, returning its minor version.
+func parseGoVersion(v string) (int, bool) {
+	if !strings.HasPrefix(v, "go1") {
+		return 0, false
+	} else if v == "go1" {
+		return 0, true
+	}
+	rest := strings.TrimPrefix(v, "go1.")
+	if i := strings.Index(rest, "."); i >= 0 {
+		rest = rest[:i]
+	}
+	var minor int
+	if _, err := fmt.Sscanf(rest, "%d", &minor); err != nil || fmt.Sprint(minor) != rest {
+		return 0, false
+	}
+	return minor, true
+}
+
+// Reports whether t is an uninstantiated generic type.
+func hasTypeParams(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.TypeParams().Len() != named.TypeArgs().Len()
+}
+
+// Returns the expression for the value of a constant. Untyped numeric
+// constants are declared with their exact value, and all others by
+// referring to the constant itself.
+func constValue(obj *types.Const, qualified string) (string, error) {
+	basic, ok := obj.Type().(*types.Basic)
+	if !ok || basic.Info() & types.IsUntyped == 0 || basic.Info() & types.IsNumeric == 0 {
+		return qualified, nil
+	}
+	var t string
+	switch basic.Kind() {
+	case types.UntypedInt:
+		t = "eval.ConstInt"
+	case types.UntypedRune:
+		t = "eval.ConstRune"
+	case types.UntypedFloat:
+		t = "eval.ConstFloat"
+	case types.UntypedComplex:
+		t = "eval.ConstComplex"
+	}
+	v := obj.Val()
+	re, err := exactString(constant.Real(v))
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", qualified, err)
+	}
+	im, err := exactString(constant.Imag(v))
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", qualified, err)
+	}
+	return fmt.Sprintf("eval.NewConstNumber(%s, %q, %q)", t, re, im), nil
+}
+
+// Returns the exact value of the real constant v as an integer or fraction.
+func exactString(v constant.Value) (string, error) {
+	switch x := constant.Val(v).(type) {
+	case int64:
+		return fmt.Sprint(x), nil
+	case *big.Int:
+		return x.String(), nil
+	case *big.Rat:
+		return x.RatString(), nil
+	case *big.Float:
+		if r, _ := x.Rat(nil); r != nil {
+			return r.RatString(), nil
+		}
+	}
+	return "", fmt.Errorf("cannot represent constant %v exactly", v)
+}
+
+// Returns the default name of the func returning the Env of package name.
+func envFuncName(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return strings.Replace(string(runes), "_", "", -1) + "Env"
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+const testSrc = `
+package geo
+
+const Pi = 3.14159265358979323846264338327950288419716939937510582097494459
+const Big = 1 << 64
+const Name = "geo"
+const I = 2i
+const Unit Meters = 1
+
+type Meters float64
+type Set[T comparable] map[T]bool
+
+var Origin Point
+
+type Point struct{ X, Y Meters }
+
+func Distance(p, q Point) Meters { return 0 }
+func Keys[T comparable](s Set[T]) []T { return nil }
+func unexported() {}
+`
+
+func checkTestSrc(t *testing.T) *types.Package {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "geo.go", testSrc, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := new(types.Config).Check("example.com/geo", fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+func TestGenerator(t *testing.T) {
+	pkg := checkTestSrc(t)
+	g := &Generator{Package: "envs", Register: true}
+	g.Add(pkg, "")
+	src, err := g.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(src)
+	for _, expected := range []string{
+		`package envs`,
+		`geopkg "example.com/geo"`,
+		`eval.RegisterPackage("example.com/geo", GeoEnv())`,
+		`env.Path = "example.com/geo"`,
+		`env.Consts["Pi"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "314159265358979323846264338327950288419716939937510582097494459/100000000000000000000000000000000000000000000000000000000000000", "0"))`,
+		`env.Consts["Big"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "18446744073709551616", "0"))`,
+		`env.Consts["I"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstComplex, "0", "2"))`,
+		`env.Consts["Name"] = reflect.ValueOf(geopkg.Name)`,
+		`env.Consts["Unit"] = reflect.ValueOf(geopkg.Unit)`,
+		`env.Vars["Origin"] = reflect.ValueOf(&geopkg.Origin)`,
+		`env.Funcs["Distance"] = reflect.ValueOf(geopkg.Distance)`,
+		`env.Types["Point"] = reflect.TypeOf((*geopkg.Point)(nil)).Elem()`,
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Generated source is missing %s", expected)
+		}
+	}
+	for _, unexpected := range []string{"Keys", "Set", "unexported"} {
+		if strings.Contains(out, unexpected) {
+			t.Errorf("Generated source contains %s", unexpected)
+		}
+	}
+	if t.Failed() {
+		t.Log(out)
+	}
+}
+
+func TestGeneratorVersions(t *testing.T) {
+	g := &Generator{Package: "envs", MinVersion: 18, API: map[string]int{
+		"example.com/geo.Distance": 21,
+		"example.com/geo.Point": 18,
+		"example.com/geo.Keys": 21,
+	}}
+	g.Add(checkTestSrc(t), "")
+	if versions := g.Versions(); len(versions) != 1 || versions[0] != 21 {
+		t.Fatalf("Wrong versions %v", versions)
+	}
+	src, err := g.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(src)
+	if strings.Contains(out, "Distance") || !strings.Contains(out, "Point") ||
+		!strings.Contains(out, "var geoEnvVersions []func(*eval.SimpleEnv)") {
+		t.Fatalf("Wrong source\n%s", out)
+	}
+	if src, err = g.VersionSource(21); err != nil {
+		t.Fatal(err)
+	}
+	out = string(src)
+	for _, expected := range []string{
+		"//go:build go1.21",
+		"geoEnvVersions = append(geoEnvVersions, func(env *eval.SimpleEnv) {",
+		`env.Funcs["Distance"] = reflect.ValueOf(geopkg.Distance)`,
+	} {
+		if !strings.Contains(out, expected) {
+			t.Fatalf("Versioned source is missing %s\n%s", expected, out)
+		}
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	for line, expected := range map[string]string{
+		"pkg strings, func Cut(string, string) (string, string, bool)": "strings.Cut",
+		"pkg os (linux-arm), const O_SYNC = 1052672": "os.O_SYNC",
+		"pkg os, type Root struct": "os.Root",
+		"pkg iter, type Seq[$0 interface{}] func(func($0) bool)": "iter.Seq",
+		"pkg os/exec, type Cmd struct, WaitDelay time.Duration": "",
+		"pkg strings, method (*Builder) Cap() int": "",
+	} {
+		if key, _ := apiKey(line); key != expected {
+			t.Errorf("apiKey(%q) = %q, expected %q", line, key, expected)
+		}
+	}
+}
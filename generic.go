@@ -0,0 +1,138 @@
+package eval
+
+import (
+	"go/ast"
+	"reflect"
+	"sync"
+)
+
+// Generic holds the registered instantiations of a generic host func or
+// type. reflect cannot instantiate generics, so the host must register each
+// instantiation it wishes to expose with AddGenericFunc or AddGenericType.
+// The instantiations are stored in an Env as a func under the generic name,
+// so that F[int] or pkg.Set[string] select the instantiation with those type
+// arguments. Type arguments of generic func calls may be omitted when the
+// call arguments match exactly one registered instantiation.
+type Generic struct {
+	name string
+
+	mu        sync.RWMutex
+	instances []genericInstance
+	isType    bool
+}
+
+type genericInstance struct {
+	targs []reflect.Type
+
+	// Exactly one of fun and typ is set
+	fun reflect.Value
+	typ reflect.Type
+}
+
+var genericType = reflect.TypeOf(&Generic{})
+
+// Register f as the instantiation name[targs...] of a generic host func,
+// for example
+//
+//  AddGenericFunc(env, "Contains", []reflect.Type{reflect.TypeOf([]int{}), reflect.TypeOf(0)},
+//  	reflect.ValueOf(slices.Contains[[]int, int]))
+func AddGenericFunc(env Env, name string, targs []reflect.Type, f reflect.Value) {
+	addGenericInstance(env, name, genericInstance{targs: targs, fun: f}, false)
+}
+
+// Register t as the instantiation name[targs...] of a generic host type.
+func AddGenericType(env Env, name string, targs []reflect.Type, t reflect.Type) {
+	addGenericInstance(env, name, genericInstance{targs: targs, typ: t}, true)
+}
+
+func addGenericInstance(env Env, name string, instance genericInstance, isType bool) {
+	var g *Generic
+	if v := env.Func(name); v.IsValid() && v.Type() == genericType {
+		g = v.Interface().(*Generic)
+	} else {
+		g = &Generic{name: name, isType: isType}
+		env.AddFunc(name, reflect.ValueOf(g))
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i, other := range g.instances {
+		if sameTypes(other.targs, instance.targs) {
+			g.instances[i] = instance
+			return
+		}
+	}
+	g.instances = append(g.instances, instance)
+}
+
+func (g *Generic) Name() string {
+	return g.name
+}
+
+// The number of type parameters, taken from the registered instantiations.
+func (g *Generic) numTypeParams() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if len(g.instances) == 0 {
+		return 0
+	}
+	return len(g.instances[0].targs)
+}
+
+// Returns the instantiations whose leading type arguments are targs.
+func (g *Generic) candidates(targs []reflect.Type) []genericInstance {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	var candidates []genericInstance
+	for _, instance := range g.instances {
+		if len(targs) <= len(instance.targs) && sameTypes(instance.targs[:len(targs)], targs) {
+			candidates = append(candidates, instance)
+		}
+	}
+	return candidates
+}
+
+func sameTypes(x, y []reflect.Type) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the Generic named by expr, which must be an identifier or a
+// package selector, together with the checked expr. Returns nil if expr
+// does not name a Generic.
+func lookupGeneric(expr ast.Expr, env Env) (*Generic, Expr) {
+	switch node := skipParens(expr).(type) {
+	case *ast.Ident:
+		for ; env != nil; env = env.PopScope() {
+			if v := env.Func(node.Name); v.IsValid() {
+				if v.Type() != genericType {
+					return nil, nil
+				}
+				return v.Interface().(*Generic), &Ident{Ident: node, source: envFunc}
+			} else if env.Var(node.Name).IsValid() || env.Const(node.Name).IsValid() || env.Type(node.Name) != nil {
+				return nil, nil
+			}
+		}
+	case *ast.SelectorExpr:
+		ident, ok := node.X.(*ast.Ident)
+		if !ok {
+			return nil, nil
+		}
+		pkg := env.Pkg(ident.Name)
+		if pkg == nil {
+			return nil, nil
+		}
+		if v := pkg.Func(node.Sel.Name); v.IsValid() && v.Type() == genericType {
+			sel := &SelectorExpr{SelectorExpr: node, X: &Ident{Ident: ident}, Sel: &Ident{Ident: node.Sel}}
+			sel.pkgName = ident.Name
+			return v.Interface().(*Generic), sel
+		}
+	}
+	return nil, nil
+}
@@ -107,7 +107,9 @@ func expectCheckError(t *testing.T, expr string, env Env, errorString ...string)
 	} else if e, ok := s.(*ast.ExprStmt); ok {
 		_, errs = CheckExpr(e.X, env)
 	} else {
-		_, errs = checkStmt(s, env, checkCtx{})
+		var astmt Stmt
+		astmt, errs = checkStmt(s, env, checkCtx{})
+		errs = append(errs, checkBranches(astmt)...)
 	}
 	if errs != nil {
 		var i int
@@ -12,6 +12,12 @@ import (
 // Some like this should really be part of the reflect package.
 func Inspect(val reflect.Value) string {
 
+	// Values of types declared by interpreted code stored in interfaces
+	if val.IsValid() {
+		if named := boxedNamed(val.Type()); named != nil {
+			return InspectTyped(val.Field(0), named)
+		}
+	}
 	if val.CanInterface() {
 		if s, ok := val.Interface().(fmt.Stringer); ok {
 			return s.String()
@@ -46,12 +52,14 @@ func Inspect(val reflect.Value) string {
 		sep := "{\n\t"
 		unexported := false
 		for i := 0; i < n; i++ {
-			name  := val.Type().Field(i).Name
-			if unicode.IsLower([]rune(name)[0]) {
+			sfield := val.Type().Field(i)
+			name  := sfield.Name
+			// Fields of interpreted struct types are visible to the interpreter
+			if unicode.IsLower([]rune(name)[0]) && sfield.PkgPath != interpPkgPath {
 				unexported = true
 				continue
 			}
-			field := val.Field(i)
+			field := fieldByIndex(val, sfield.Index)
 			str += fmt.Sprintf("%s%s: %s", sep, name, InspectShort(field))
 			sep = ",\n\t"
 		}
@@ -103,14 +111,30 @@ func Inspect(val reflect.Value) string {
 	}
 }
 
+// As Inspect, but val is of type t, which may be a type declared by
+// interpreted code. Methods declared on such types are only known to the
+// interpreter, so a String() string method is only found through t.
+func InspectTyped(val reflect.Value, t reflect.Type) string {
+	if named, ok := t.(*Named); ok {
+		if m := named.lookupMethod("String"); m != nil && m.Type == reflect.FuncOf(
+			[]reflect.Type{m.Type.In(0)}, []reflect.Type{reflect.TypeOf("")}, false) {
+			if _, ok := named.MethodByName("String"); ok || val.CanAddr() {
+				return m.bind(val).Call(nil)[0].String()
+			}
+		}
+	}
+	return Inspect(val)
+}
+
 // Returns type{...} for composite lits
 func InspectShort(val reflect.Value) string {
 	switch val.Kind() {
 	case reflect.Slice, reflect.Array, reflect.Struct, reflect.Map:
-		return fmt.Sprintf("%v{...}", val.Type())
-	default:
-		return Inspect(val)
+		if boxedNamed(val.Type()) == nil {
+			return fmt.Sprintf("%v{...}", val.Type())
+		}
 	}
+	return Inspect(val)
 }
 
 type mapKeys []reflect.Value
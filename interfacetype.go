@@ -0,0 +1,199 @@
+package eval
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Interface is a non-empty interface type declared by interpreted code.
+// reflect cannot create interface types, so values of an Interface are
+// stored as interface{} and the method set is only enforced by the checker
+// and when asserting dynamic types.
+//
+// Interfaces are only created through NewInterface, which returns the same
+// *Interface for identical method sets so that types may be compared with ==.
+// Types within method signatures are unhacked, so a method returning an
+// Interface is represented as returning interface{}.
+type Interface struct {
+	reflect.Type
+	methods []reflect.Method
+
+	// Variables of an Interface type are stored in a distinct box type,
+	// so that the Interface can be recovered from the variable's type.
+	box reflect.Type
+}
+
+var interfaceTypes = struct {
+	sync.Mutex
+	m map[string][]*Interface
+
+	// Read without the lock by every variable access
+	boxes sync.Map // map[reflect.Type]*Interface
+}{m: map[string][]*Interface{}}
+
+// Create an interface type with the given method set. Only the Name and
+// Type of each method are used, where Type is a func type without receiver.
+// An empty method set yields the empty interface.
+func NewInterface(methods []reflect.Method) reflect.Type {
+	if len(methods) == 0 {
+		return emptyInterface
+	}
+	iface := &Interface{Type: emptyInterface}
+	iface.methods = make([]reflect.Method, len(methods))
+	for i, m := range methods {
+		iface.methods[i] = reflect.Method{Name: m.Name, Type: m.Type}
+	}
+	sort.Sort(methodsByName(iface.methods))
+	for i := range iface.methods {
+		iface.methods[i].Index = i
+	}
+
+	interfaceTypes.Lock()
+	defer interfaceTypes.Unlock()
+	key := iface.String()
+	for _, other := range interfaceTypes.m[key] {
+		if other.sameMethods(iface) {
+			return other
+		}
+	}
+	tag := fmt.Sprintf("eval:%q", fmt.Sprintf("%s#%d", key, len(interfaceTypes.m[key])))
+	iface.box = reflect.StructOf([]reflect.StructField{
+		{Name: "V", Type: emptyInterface, Tag: reflect.StructTag(tag)},
+	})
+	interfaceTypes.m[key] = append(interfaceTypes.m[key], iface)
+	interfaceTypes.boxes.Store(iface.box, iface)
+	return iface
+}
+
+func (iface *Interface) sameMethods(other *Interface) bool {
+	if len(iface.methods) != len(other.methods) {
+		return false
+	}
+	for i, m := range iface.methods {
+		if m.Name != other.methods[i].Name || m.Type != other.methods[i].Type {
+			return false
+		}
+	}
+	return true
+}
+
+func (*Interface) Name() string {
+	return ""
+}
+
+func (iface *Interface) String() string {
+	s := make([]string, len(iface.methods))
+	for i, m := range iface.methods {
+		s[i] = m.Name + strings.TrimPrefix(m.Type.String(), "func")
+	}
+	return "interface { " + strings.Join(s, "; ") + " }"
+}
+
+func (iface *Interface) NumMethod() int {
+	return len(iface.methods)
+}
+
+func (iface *Interface) Method(i int) reflect.Method {
+	return iface.methods[i]
+}
+
+func (iface *Interface) MethodByName(name string) (reflect.Method, bool) {
+	i := sort.Search(len(iface.methods), func(i int) bool {
+		return iface.methods[i].Name >= name
+	})
+	if i < len(iface.methods) && iface.methods[i].Name == name {
+		return iface.methods[i], true
+	}
+	return reflect.Method{}, false
+}
+
+func (iface *Interface) Implements(u reflect.Type) bool {
+	return implements(iface, u)
+}
+
+func (iface *Interface) AssignableTo(u reflect.Type) bool {
+	return typeAssignableTo(iface, u)
+}
+
+func (iface *Interface) ConvertibleTo(u reflect.Type) bool {
+	return typeAssignableTo(iface, u)
+}
+
+type methodsByName []reflect.Method
+
+func (m methodsByName) Len() int           { return len(m) }
+func (m methodsByName) Less(i, j int) bool { return m[i].Name < m[j].Name }
+func (m methodsByName) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+
+// Determine if t implements the interface iface, either of which may be
+// an *Interface.
+func implements(t, iface reflect.Type) bool {
+	_, virtualT := t.(*Interface)
+	_, virtualI := iface.(*Interface)
+	if !virtualT && !virtualI {
+		// Methods of a Named only satisfy host interfaces with adapters,
+		// as they are not part of the stored value.
+		if _, named := t.(*Named); named && adapterFor(iface) != nil {
+			return missingMethod(iface, t) == ""
+		}
+		return unhackType(t).Implements(iface)
+	}
+	return missingMethod(iface, t) == ""
+}
+
+// Returns the signature of method name of t, excluding the receiver.
+func methodSignature(t reflect.Type, name string) (reflect.Type, bool) {
+	method, ok := t.MethodByName(name)
+	if !ok {
+		return nil, false
+	} else if named, ok := t.(*Named); ok {
+		return named.lookupMethod(name).signature(), true
+	} else if t.Kind() == reflect.Interface {
+		return method.Type, true
+	}
+	// Type.Method() on a non interface type includes the receiver
+	return reflect.Zero(unhackType(t)).Method(method.Index).Type(), true
+}
+
+// Allocate a new variable of type t to be stored in an Env.
+func newVar(t reflect.Type) reflect.Value {
+	if iface, ok := t.(*Interface); ok {
+		return reflect.New(iface.box)
+	} else if named, ok := t.(*Named); ok {
+		return reflect.New(named.box)
+	}
+	return hackedNew(t)
+}
+
+// Returns the settable value of a variable stored in an Env.
+func varValue(v reflect.Value) reflect.Value {
+	v = v.Elem()
+	if v.Kind() == reflect.Struct && (boxedInterface(v.Type()) != nil || boxedNamed(v.Type()) != nil) {
+		return v.Field(0)
+	}
+	return v
+}
+
+// Returns the type of a variable stored in an Env.
+func varType(v reflect.Value) reflect.Type {
+	t := v.Type().Elem()
+	if iface := boxedInterface(t); iface != nil {
+		return iface
+	} else if named := boxedNamed(t); named != nil {
+		return named
+	}
+	return t
+}
+
+func boxedInterface(t reflect.Type) *Interface {
+	if t.Kind() != reflect.Struct || t.NumField() != 1 {
+		return nil
+	}
+	if iface, ok := interfaceTypes.boxes.Load(t); ok {
+		return iface.(*Interface)
+	}
+	return nil
+}
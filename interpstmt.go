@@ -22,15 +22,7 @@ func InterpStmt(stmt Stmt, env Env) (last *State, err error) {
 					return nil, err
 				}
 			}
-			for i, lhs := range s.Lhs {
-				if name, ok := s.newNames[i]; !ok {
-					assign(lhs, rs[i], env)
-				} else if name != "_" {
-					v := hackedNew(s.types[i])
-					v.Elem().Set(rs[i])
-					env.AddVar(name, v)
-				}
-			}
+			assignValues(s, rs, env)
 		} else {
 			for i, lhs := range s.Lhs {
 				r, err := evalTypedExpr(s.Rhs[i], s.types[i:i+1], env)
@@ -40,8 +32,8 @@ func InterpStmt(stmt Stmt, env Env) (last *State, err error) {
 				if name, ok := s.newNames[i]; !ok {
 					assign(lhs, r[0], env)
 				} else if name != "_" {
-					v := hackedNew(s.types[i])
-					v.Elem().Set(r[0])
+					v := newVar(s.types[i])
+					varValue(v).Set(r[0])
 					env.AddVar(name, v)
 				}
 			}
@@ -52,11 +44,25 @@ func InterpStmt(stmt Stmt, env Env) (last *State, err error) {
 		return interpBlock(s.List, env)
 	case *CaseClause:
 		return interpBlock(s.Body, env)
+	case *DeclStmt:
+		if m := s.Method; m != nil && m.method != nil {
+			f, _ := evalFuncLit(m.Lit, env)
+			m.method.setFunc(f)
+		}
+		for _, spec := range s.Specs {
+			if err := interpSpec(spec, env); err != nil {
+				return nil, err
+			}
+		}
+	case *DeferStmt:
+		return nil, interpDeferStmt(s, env)
 	case *EmptyStmt:
 		return nil, nil
 	case *ExprStmt:
 		_, err := EvalExpr(s.X, env)
 		return nil, err
+	case *GoStmt:
+		return nil, interpGoStmt(s, env)
 	case *IfStmt:
 		env = env.PushScope()
 		if _ , err = InterpStmt(s.Init, env); err != nil {
@@ -85,7 +91,7 @@ func InterpStmt(stmt Stmt, env Env) (last *State, err error) {
 				return last, err
 			}
 			if last != nil {
-				if branch, ok := last.Last.(*BranchStmt); ok {
+				if branch, ok := last.Last.(*BranchStmt); ok && branch.Tok != token.GOTO {
 					// Are we the target of this branch?
 					if branch.Label == nil || branch.Label.Name == s.label {
 						last = nil
@@ -101,10 +107,16 @@ cont:
 				return nil, err
 			}
 		}
+	case *RangeStmt:
+		return interpRange(s, env)
 	case *LabeledStmt:
 		return InterpStmt(s.Stmt, env)
 	case *ReturnStmt:
 		return &State{s, env}, nil
+	case *SelectStmt:
+		return interpSelectStmt(s, env)
+	case *SendStmt:
+		return nil, interpSendStmt(s, env)
 	case *SwitchStmt:
 		env = env.PushScope()
 		t := knownType{s.tagT}
@@ -115,20 +127,39 @@ cont:
 		if err != nil {
 			return nil, err
 		}
-		env = env.PushScope()
-		for _, stmt := range s.Body.List {
+		match := -1
+	cases:
+		for i, stmt := range s.Body.List {
 			clause := stmt.(*CaseClause)
+			if clause == s.def {
+				match = i
+			}
 			for _, expr := range clause.List {
 				if sw, err := evalTypedExpr(expr, t, env); err != nil {
 					return nil, err
 				} else if eq, err := equal(tag[0], sw[0]); err != nil {
 					return nil, err
 				} else if eq {
-					return InterpStmt(clause, env)
+					match = i
+					break cases
 				}
 			}
 		}
-		return InterpStmt(s.def, env)
+		if match == -1 {
+			return nil, nil
+		}
+		// A fallthrough continues with the body of the next clause
+		for i := match; i < len(s.Body.List); i += 1 {
+			clause := s.Body.List[i].(*CaseClause)
+			if last, err = InterpStmt(clause, env.PushScope()); err != nil {
+				return last, err
+			} else if last == nil {
+				return nil, nil
+			} else if branch, ok := last.Last.(*BranchStmt); !ok || branch.Tok != token.FALLTHROUGH {
+				return switchBranch(last, s.label), nil
+			}
+		}
+		return nil, nil
 
 	case *TypeSwitchStmt:
 		env = env.PushScope()
@@ -140,32 +171,49 @@ cont:
 		if err != nil {
 			return nil, err
 		}
-		// interface.elem()
-		dynamicX := x[0].Elem()
-		dynamicT := dynamicX.Type()
+		// interface.elem(), nil interfaces match no types
+		dynamicX, dynamicT := dynamicValue(x[0])
 
-		env = env.PushScope()
-		if name := s.Name(); name != "" {
-			// dynamicX may not be addressable
-			x := reflect.New(dynamicT)
-			x.Elem().Set(dynamicX)
-			env.AddVar(name, x)
+		var match *CaseClause
+		if s.def != nil {
+			match = s.def.(*CaseClause)
 		}
-
+	clauses:
 		for _, stmt := range s.Body.List {
 			clause := stmt.(*CaseClause)
 			for _, expr := range clause.List {
 				t := expr.KnownType()[0]
+				if dynamicT == nil {
+					continue
+				} else if t.Kind() == reflect.Interface && implements(dynamicT, t) || t == dynamicT {
+					match = clause
+					break clauses
+				}
+			}
+		}
+
+		env = env.PushScope()
+		if name := s.Name(); name != "" && match != nil {
+			// In single type clauses the variable has that type,
+			// otherwise it has the type of the tag
+			t, v := s.Tag().KnownType()[0], x[0]
+			if len(match.List) == 1 {
+				t, v = match.List[0].KnownType()[0], dynamicX
 				if t.Kind() == reflect.Interface {
-					if dynamicT.Implements(t) {
-						return InterpStmt(clause, env)
-					}
-				} else if t == dynamicT {
-					return InterpStmt(clause, env)
+					v = interfaceValue(dynamicX, dynamicT, t)
 				}
 			}
+			variable := newVar(t)
+			varValue(variable).Set(v)
+			env.AddVar(name, variable)
+		}
+		if match == nil {
+			return nil, nil
 		}
-		return InterpStmt(s.def, env)
+		if last, err = InterpStmt(match, env); err != nil {
+			return last, err
+		}
+		return switchBranch(last, s.label), nil
 
 	default:
 		panic(dytc(fmt.Sprintf("Unsupported statement %T", s)))
@@ -173,6 +221,69 @@ cont:
 	return nil, nil
 }
 
+func interpSpec(spec Spec, env Env) error {
+	switch s := spec.(type) {
+	case *ImportSpec:
+		interpImportSpec(s, env)
+	case *TypeSpec:
+		if s.Name.Name != "_" {
+			env.AddType(s.Name.Name, s.Name.KnownType()[0])
+		}
+	case *ValueSpec:
+		if s.tok == token.CONST {
+			for i, name := range s.Names {
+				if name.Name != "_" {
+					env.AddConst(name.Name, s.consts[i])
+				}
+			}
+			return nil
+		}
+		// All values are evaluated before any names are declared
+		rs := make([]reflect.Value, len(s.Names))
+		if len(s.Values) == 0 {
+			for i, t := range s.types {
+				rs[i] = hackedNew(t).Elem()
+			}
+		} else if len(s.Values) == 1 && len(s.Names) > 1 {
+			var err error
+			if rs, err = evalTypedExpr(s.Values[0], s.types, env); err != nil {
+				if _, ok := err.(PanicInterfaceConversion); !ok || len(s.types) != 2 {
+					return err
+				}
+			}
+		} else {
+			for i, value := range s.Values {
+				if r, err := evalTypedExpr(value, s.types[i:i+1], env); err != nil {
+					return err
+				} else {
+					rs[i] = r[0]
+				}
+			}
+		}
+		for i, name := range s.Names {
+			if name.Name != "_" {
+				v := newVar(s.types[i])
+				varValue(v).Set(rs[i])
+				env.AddVar(name.Name, v)
+			}
+		}
+	}
+	return nil
+}
+
+// Assign or define the lhs of s to rs, the results of its single rhs.
+func assignValues(s *AssignStmt, rs []reflect.Value, env Env) {
+	for i, lhs := range s.Lhs {
+		if name, ok := s.newNames[i]; !ok {
+			assign(lhs, rs[i], env)
+		} else if name != "_" {
+			v := newVar(s.types[i])
+			varValue(v).Set(rs[i])
+			env.AddVar(name, v)
+		}
+	}
+}
+
 func assign(lhs Expr, rhs reflect.Value, env Env) error {
 	lhs = skipSuperfluousParens(lhs)
 	// Always evaluate even if we are doing a map index assign. There are some nasty
@@ -185,12 +296,218 @@ func assign(lhs Expr, rhs reflect.Value, env Env) error {
 		m, _ := evalTypedExpr(index.X, knownType{mT}, env)
 		k, _ := evalTypedExpr(index.Index, knownType{mT.Elem()}, env)
 		m[0].SetMapIndex(k[0], rhs)
+	} else if sel, ok := lhs.(*SelectorExpr); ok && sel.pkgName != "" {
+		// Package variables belong to the host
+		l[0].Set(hostValue(rhs, l[0].Type()))
 	} else {
 		l[0].Set(rhs)
 	}
 	return nil
 }
 
+func interpSendStmt(s *SendStmt, env Env) (err error) {
+	ch, err := EvalExpr(s.Chan, env)
+	if err != nil {
+		return err
+	}
+	v, err := evalTypedExpr(s.Value, knownType{ch[0].Type().Elem()}, env)
+	if err != nil {
+		return err
+	}
+	// Sending on a closed channel panics
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError(r)
+		}
+	}()
+	ch[0].Send(v[0])
+	return nil
+}
+
+func interpSelectStmt(s *SelectStmt, env Env) (*State, error) {
+	// Channels and values to send are evaluated once, in source order
+	cases := make([]reflect.SelectCase, len(s.Body.List))
+	for i, stmt := range s.Body.List {
+		clause := stmt.(*CommClause)
+		if send, ok := clause.Comm.(*SendStmt); ok {
+			ch, err := EvalExpr(send.Chan, env)
+			if err != nil {
+				return nil, err
+			}
+			v, err := evalTypedExpr(send.Value, knownType{ch[0].Type().Elem()}, env)
+			if err != nil {
+				return nil, err
+			}
+			cases[i] = reflect.SelectCase{Dir: reflect.SelectSend, Chan: ch[0], Send: v[0]}
+		} else if clause.recv != nil {
+			ch, err := EvalExpr(clause.recv.X, env)
+			if err != nil {
+				return nil, err
+			}
+			cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: ch[0]}
+		} else {
+			cases[i] = reflect.SelectCase{Dir: reflect.SelectDefault}
+		}
+	}
+
+	chosen, recv, recvOk, err := selectCases(cases)
+	if err != nil {
+		return nil, err
+	}
+	clause := s.Body.List[chosen].(*CommClause)
+	env = env.PushScope()
+	if assign, ok := clause.Comm.(*AssignStmt); ok {
+		assignValues(assign, []reflect.Value{recv, reflect.ValueOf(recvOk)}, env)
+	}
+	last, err := interpBlock(clause.Body, env)
+	if err != nil {
+		return nil, err
+	} else if last != nil {
+		if branch, ok := last.Last.(*BranchStmt); ok && branch.Tok == token.BREAK {
+			if branch.Label == nil || branch.Label.Name == s.label {
+				return nil, nil
+			}
+		}
+	}
+	return last, nil
+}
+
+// Calls reflect.Select, returning the panic of a send on a closed channel
+// as an error.
+func selectCases(cases []reflect.SelectCase) (chosen int, recv reflect.Value, recvOk bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError(r)
+		}
+	}()
+	chosen, recv, recvOk = reflect.Select(cases)
+	return
+}
+
+func interpRange(s *RangeStmt, env Env) (*State, error) {
+	env = env.PushScope()
+	xs, err := evalTypedExpr(s.X, knownType{s.xT}, env)
+	if err != nil {
+		return nil, err
+	}
+	x := xs[0]
+
+	// Execute the body for a single iteration. done is true if the
+	// loop should terminate, in which case last is returned.
+	iterate := func(k, v reflect.Value) (last *State, done bool, err error) {
+		iterEnv := env.PushScope()
+		for i, expr := range []Expr{s.Key, s.Value} {
+			val := k
+			if i == 1 {
+				val = v
+			}
+			if expr == nil || !val.IsValid() || isBlankIdentifier(expr) {
+				continue
+			} else if s.Tok == token.DEFINE {
+				// Each iteration has its own copy of the iteration variables
+				t := s.keyT
+				if i == 1 {
+					t = s.valueT
+				}
+				variable := newVar(t)
+				varValue(variable).Set(val)
+				iterEnv.AddVar(expr.(*Ident).Name, variable)
+			} else if err := assign(expr, val, iterEnv); err != nil {
+				return nil, true, err
+			}
+		}
+		if last, err = InterpStmt(s.Body, iterEnv); err != nil {
+			return last, true, err
+		} else if last != nil {
+			if branch, ok := last.Last.(*BranchStmt); ok && branch.Tok != token.GOTO {
+				// Are we the target of this branch?
+				if branch.Label == nil || branch.Label.Name == s.label {
+					return nil, branch.Tok != token.CONTINUE, nil
+				}
+			}
+			return last, true, nil
+		}
+		return nil, false, nil
+	}
+
+	// The value is only computed if it is used
+	needValue := s.Value != nil && !isBlankIdentifier(s.Value)
+	var last *State
+	var done bool
+	switch s.xT.Kind() {
+	case reflect.Ptr:
+		if x.IsNil() {
+			if needValue {
+				return nil, PanicInvalidDereference{}
+			}
+			for i := 0; i < s.xT.Elem().Len() && !done; i += 1 {
+				last, done, err = iterate(reflect.ValueOf(i), reflect.Value{})
+			}
+			return last, err
+		}
+		x = x.Elem()
+		fallthrough
+	case reflect.Array, reflect.Slice:
+		if x.Kind() == reflect.Array && needValue {
+			// The range expression is evaluated once, arrays are copied
+			a := reflect.New(x.Type()).Elem()
+			a.Set(x)
+			x = a
+		}
+		n := x.Len()
+		for i := 0; i < n && !done; i += 1 {
+			var v reflect.Value
+			if needValue {
+				v = x.Index(i)
+			}
+			last, done, err = iterate(reflect.ValueOf(i), v)
+		}
+	case reflect.String:
+		for i, r := range x.String() {
+			if last, done, err = iterate(reflect.ValueOf(i), reflect.ValueOf(r)); done {
+				break
+			}
+		}
+	case reflect.Map:
+		iter := x.MapRange()
+		for !done && iter.Next() {
+			var v reflect.Value
+			if needValue {
+				v = iter.Value()
+			}
+			last, done, err = iterate(iter.Key(), v)
+		}
+	case reflect.Chan:
+		for !done {
+			v, ok := x.Recv()
+			if !ok {
+				break
+			}
+			last, done, err = iterate(v, reflect.Value{})
+		}
+	default:
+		// Integers
+		n := reflect.New(s.xT).Elem()
+		for !done && lessThan(n, x) {
+			last, done, err = iterate(n, reflect.Value{})
+			if isUnsignedInt(s.xT) {
+				n.SetUint(n.Uint() + 1)
+			} else {
+				n.SetInt(n.Int() + 1)
+			}
+		}
+	}
+	return last, err
+}
+
+// Compare two values of the same integer type
+func lessThan(x, y reflect.Value) bool {
+	if isUnsignedInt(x.Type()) {
+		return x.Uint() < y.Uint()
+	}
+	return x.Int() < y.Int()
+}
+
 func interpBlock(list []Stmt, env Env) (last *State, err error) {
 	for i := 0; i < len(list); i += 1 {
 		if last, err = InterpStmt(list[i], env); err != nil {
@@ -204,30 +521,101 @@ func interpBlock(list []Stmt, env Env) (last *State, err error) {
 	return last, nil
 }
 
-// Really naive implementation which simply scans block for the branch target
+// Scan the block for the target of a goto. The checker ensures the target
+// is in the block of the goto or an enclosing block, so other branches are
+// returned to be handled by the enclosing statements. The returned index
+// precedes the labeled stmt, as interpBlock increments it.
 func branch(list []Stmt, last *State, env Env) (*State, int) {
 	branch, ok := last.Last.(*BranchStmt)
-	if !ok {
+	if !ok || branch.Tok != token.GOTO {
 		return last, 0
 	}
-
-	// breaks should go to the next stmt, goto should go to the labeled stmt
-	brk := 0
-	if branch.Tok == token.GOTO {
-		brk = -1
-	}
 	for i, stmt := range list {
-		switch s := stmt.(type) {
-		case *LabeledStmt:
-			if branch.Label != nil && branch.Label.Name == s.Label.Name {
-				return nil, i+brk
+		if s, ok := stmt.(*LabeledStmt); ok && branch.Label.Name == s.Label.Name {
+			return nil, i-1
+		}
+	}
+	return last, 0
+}
+
+// Consume a break targeting a switch statement with the given label.
+func switchBranch(last *State, label string) *State {
+	if last != nil {
+		if branch, ok := last.Last.(*BranchStmt); ok && branch.Tok == token.BREAK {
+			if branch.Label == nil || branch.Label.Name == label {
+				return nil
 			}
-		// TODO[crc] add SelectStmt and RangeStmt here when implemented
-		case *ForStmt, *SwitchStmt, *TypeSwitchStmt:
-			if branch.Label == nil {
-				return nil, i+brk
+		}
+	}
+	return last
+}
+
+// The function value and arguments are evaluated immediately, and the call
+// is run when the enclosing function literal returns or panics.
+func interpDeferStmt(s *DeferStmt, env Env) error {
+	frame := envFrame(env)
+	call := s.Call
+	if call.isBuiltin {
+		args, err := evalBuiltinStmtArgs(call, env)
+		if err != nil {
+			return err
+		}
+		frame.defers = append(frame.defers, deferredCall{call: call, args: args, env: env})
+		return nil
+	}
+	v, err := EvalExpr(call.Fun, env)
+	if err != nil {
+		return err
+	}
+	fun := v[0]
+	args, err := evalCallArgs(call, fun, env)
+	if err != nil {
+		return err
+	}
+	fun, args = copyValues([]reflect.Value{fun})[0], copyValues(args)
+	frame.defers = append(frame.defers, deferredCall{call: call, fun: fun, args: args})
+	return nil
+}
+
+// The function value and arguments are evaluated in the calling goroutine,
+// the call itself runs on a new goroutine. A panic in that goroutine is
+// passed to GoPanicHandler.
+func interpGoStmt(s *GoStmt, env Env) error {
+	call := s.Call
+	if call.isBuiltin {
+		args, err := evalBuiltinStmtArgs(call, env)
+		if err != nil {
+			return err
+		}
+		go func() {
+			defer recoverGoPanic()
+			if err := callBuiltinStmt(call, args, env); err != nil {
+				panic(err)
 			}
+		}()
+		return nil
+	}
+	fun, args, err := evalCallFunArgs(call, env)
+	if err != nil {
+		return err
+	} else if fun.IsNil() {
+		return PanicInvalidDereference{}
+	}
+	fun, args = copyValues([]reflect.Value{fun})[0], copyValues(args)
+	go func() {
+		defer recoverGoPanic()
+		if _, err := callFun(call, fun, args, nil); err != nil {
+			panic(err)
+		}
+	}()
+	return nil
+}
+
+func recoverGoPanic() {
+	if r := recover(); r != nil {
+		err := panicError(r)
+		if handler := GoPanicHandler; handler != nil {
+			handler(err)
 		}
 	}
-	return last, 0
 }
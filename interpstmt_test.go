@@ -1,7 +1,10 @@
 package eval
 
 import (
+	"os"
 	"reflect"
+	"strconv"
+	"sync"
 	"testing"
 )
 
@@ -422,3 +425,610 @@ func TestLabeledBreakLoopFromUnlabeled(t *testing.T) {
 	expectResult(t, "x", env, 1)
 }
 
+
+func TestInterpGotoOutOfLoop(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 0", env)
+	block := `{
+		for {
+			x += 1
+			if x == 3 {
+				goto done
+			}
+		}
+		x = 10
+		done:
+	}`
+	expectInterp(t, block, env)
+	expectResult(t, "x", env, 3)
+}
+
+func TestInterpGotoLabeledLoop(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x, n := 0, 0", env)
+	block := `{
+		loop:
+		for i := 0; i < 2; i += 1 {
+			x += 1
+			if n < 2 {
+				n += 1
+				goto loop
+			}
+		}
+	}`
+	expectInterp(t, block, env)
+	expectResult(t, "x", env, 4)
+}
+
+func TestInterpGotoFuncLit(t *testing.T) {
+	env := MakeSimpleEnv()
+	f := `f := func() int {
+		i := 0
+		again:
+		i += 1
+		if i < 3 {
+			goto again
+		}
+		return i
+	}`
+	expectInterp(t, f, env)
+	expectResult(t, "f()", env, 3)
+}
+
+func TestSwitchFallthrough(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 0", env)
+	sw := `switch 1 {
+	case 1:
+		x += 1
+		fallthrough
+	case 2:
+		x += 10
+		fallthrough
+	default:
+		x += 100
+	case 3:
+		x += 1000
+	}`
+	expectInterp(t, sw, env)
+	expectResult(t, "x", env, 111)
+}
+
+func TestSwitchFallthroughFromDefault(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 0", env)
+	sw := `switch 4 {
+	default:
+		x += 1
+		fallthrough
+	case 1:
+		x += 10
+	case 4:
+		x += 100
+	}`
+	expectInterp(t, sw, env)
+	expectResult(t, "x", env, 100)
+	expectInterp(t, "switch 5 { default: x = 1; fallthrough; case 1: x += 10 }", env)
+	expectResult(t, "x", env, 11)
+}
+
+func TestSwitchBreak(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 0", env)
+	loop := `for i := 0; i < 3; i += 1 {
+		switch i {
+		case 1:
+			break
+		}
+		x += 1
+	}`
+	expectInterp(t, loop, env)
+	expectResult(t, "x", env, 3)
+}
+
+func TestLabeledSwitchBreak(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 0", env)
+	sw := `sw:
+	switch {
+	default:
+		for {
+			break sw
+		}
+		x = 1
+	}`
+	expectInterp(t, sw, env)
+	expectResult(t, "x", env, 0)
+}
+
+func TestTypeSwitchBreak(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 0", env)
+	loop := `for i := 0; i < 3; i += 1 {
+		switch interface{}(i).(type) {
+		case int:
+			break
+		}
+		x += 1
+	}`
+	expectInterp(t, loop, env)
+	expectResult(t, "x", env, 3)
+}
+
+func TestSwitchClauseScopes(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 0", env)
+	expectInterp(t, "switch 2 { case 1: y := 1; x = y; case 2: y := 2; x = y }", env)
+	expectResult(t, "x", env, 2)
+}
+
+func TestRangeSlice(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 0", env)
+	expectInterp(t, "for i, v := range []int{1, 2, 3} { x += i * v }", env)
+	expectResult(t, "x", env, 8)
+}
+
+func TestRangeArrayIsCopied(t *testing.T) {
+	env := MakeSimpleEnv()
+	a := [3]int{1, 2, 3}
+	env.Vars["a"] = reflect.ValueOf(&a)
+	expectInterp(t, "x := 0", env)
+	loop := `for i, v := range a {
+		a[2] = 10
+		x += i + v
+	}`
+	expectInterp(t, loop, env)
+	expectResult(t, "x", env, 9)
+}
+
+func TestRangeString(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x, y := 0, rune(0)", env)
+	expectInterp(t, `for i, r := range "aé" { x += i; y += r }`, env)
+	expectResult(t, "x", env, 1)
+	expectResult(t, "y", env, 'a' + 'é')
+}
+
+func TestRangeMap(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 0", env)
+	expectInterp(t, "for k, v := range map[int]int{1: 10, 2: 20} { x += k + v }", env)
+	expectResult(t, "x", env, 33)
+}
+
+func TestRangeChan(t *testing.T) {
+	env := MakeSimpleEnv()
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	close(ch)
+	env.Vars["ch"] = reflect.ValueOf(&ch)
+	expectInterp(t, "x := 0", env)
+	expectInterp(t, "for v := range ch { x += v }", env)
+	expectResult(t, "x", env, 3)
+}
+
+func TestRangeInt(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 0", env)
+	expectInterp(t, "for i := range 4 { x += i }", env)
+	expectResult(t, "x", env, 6)
+}
+
+func TestRangeAssign(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "k, v := 0, \"\"", env)
+	expectInterp(t, `for k, v = range []string{"a", "b"} {}`, env)
+	expectResult(t, "k", env, 1)
+	expectResult(t, "v", env, "b")
+}
+
+func TestRangeNoVars(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 0", env)
+	expectInterp(t, "for range []int{1, 2, 3} { x += 1 }", env)
+	expectResult(t, "x", env, 3)
+}
+
+func TestRangeBreakContinue(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 0", env)
+	loop := `
+	outer:
+	for _, i := range []int{1, 2, 3, 4} {
+		for j := range i {
+			if j == 1 {
+				continue outer
+			}
+		}
+		if i == 3 {
+			break
+		}
+		x += i
+	}`
+	expectInterp(t, loop, env)
+	expectResult(t, "x", env, 1)
+}
+
+func TestRangeClosure(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "fs := []func() int{}", env)
+	expectInterp(t, "for i := range 3 { fs = append(fs, func() int { return i }) }", env)
+	expectResult(t, "fs[0]() + fs[2]()", env, 2)
+}
+
+func TestDeclVarZero(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "var x []int", env)
+	expectResult(t, "x == nil", env, true)
+	expectInterp(t, "var a, b float32", env)
+	expectResult(t, "a + b", env, float32(0))
+}
+
+func TestDeclVarValues(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, `var a, b = 1, "s"`, env)
+	expectResult(t, "a", env, 1)
+	expectResult(t, "b", env, "s")
+	expectInterp(t, "var f float64 = 1", env)
+	expectResult(t, "f", env, float64(1))
+	expectInterp(t, `var v, ok = map[string]int{"a": 1}["a"]`, env)
+	expectResult(t, "v", env, 1)
+	expectResult(t, "ok", env, true)
+}
+
+func TestDeclVarShadow(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 1", env)
+	expectInterp(t, "{ var x = x + 1; _ = x }", env)
+	expectResult(t, "x", env, 1)
+}
+
+func TestDeclConstIota(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "const ( A = iota; B; C )", env)
+	expectConst(t, "C", env, NewConstInt64(2), ConstInt)
+	expectInterp(t, "const ( _ = 1 << (10 * iota); KB; MB )", env)
+	expectConst(t, "MB", env, NewConstInt64(1 << 20), ConstInt)
+	expectInterp(t, "x := float32(C)", env)
+	expectResult(t, "x", env, float32(2))
+}
+
+func TestDeclConstTyped(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "const ( K int8 = iota + 3; L )", env)
+	expectConst(t, "L", env, int8(4), reflect.TypeOf(int8(0)))
+	expectInterp(t, `const s string = "abc"`, env)
+	expectConst(t, "s", env, "abc", reflect.TypeOf(""))
+}
+
+func TestDeclType(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "type T []int", env)
+	expectInterp(t, "var x T = T{1, 2}", env)
+	expectResult(t, "len(x)", env, 2)
+}
+
+func TestDeclStruct(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "type Point struct{ x, y int }", env)
+	expectInterp(t, "var p Point", env)
+	expectInterp(t, "p.x, p.y = 1, 2", env)
+	expectInterp(t, "q := &Point{y: 3}", env)
+	expectInterp(t, "q.x += p.x + p.y", env)
+	expectResult(t, "q.x", env, 3)
+	expectResult(t, "p == Point{1, 2}", env, true)
+}
+
+func TestDeclArray(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "var buf [16]byte", env)
+	expectInterp(t, "buf[3] = 'x'", env)
+	expectInterp(t, "s := buf[2:len(buf)]", env)
+	expectResult(t, "s[1]", env, byte('x'))
+	expectConst(t, "cap(buf)", env, 16, reflect.TypeOf(0))
+	expectInterp(t, "a := [...]int{1, 2, 3}", env)
+	expectInterp(t, "b := a", env)
+	expectInterp(t, "b[0] = 4", env)
+	expectResult(t, "a == [3]int{1, 2, 3}", env, true)
+	expectResult(t, "a != b", env, true)
+}
+
+func TestDeclFunc(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "var f func(...int) (int, bool)", env)
+	expectResult(t, "f == nil", env, true)
+	expectInterp(t, "f = func(xs ...int) (int, bool) { return len(xs), true }", env)
+	expectResults(t, "f(1, 2)", env, 2, true)
+}
+
+func TestTypeSwitchFunc(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 0", env)
+	expectInterp(t, "var i interface{} = func(int) string { return \"\" }", env)
+	expectInterp(t, "switch i.(type) { case func(): x = 1; case func(int) string: x = 2 }", env)
+	expectResult(t, "x", env, 2)
+}
+
+func TestTypeSwitchInterfaceLit(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Types["L"] = reflect.TypeOf(typeAssertLen{})
+	expectInterp(t, "x := 0", env)
+	expectInterp(t, "var i interface{} = L{1, 2, 3}", env)
+	stmt := `switch v := i.(type) {
+	case interface{ Close() error }:
+		x = -1
+	case interface{ Len() int }:
+		x = v.Len()
+	}`
+	expectInterp(t, stmt, env)
+	expectResult(t, "x", env, 3)
+}
+
+func TestTypeSwitchDefaultVar(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "x := 0", env)
+	expectInterp(t, "var i interface{} = 4", env)
+	expectInterp(t, "switch v := i.(type) { case int, string: x = 1; _ = v.(int) ; default: x = -1 }", env)
+	expectResult(t, "x", env, 1)
+	expectInterp(t, "i = nil", env)
+	expectInterp(t, "switch v := i.(type) { case int: x = 2; default: _ = v; x = 3 }", env)
+	expectResult(t, "x", env, 3)
+}
+
+func TestGoStmt(t *testing.T) {
+	env := MakeSimpleEnv()
+	var wg sync.WaitGroup
+	env.Vars["wg"] = reflect.ValueOf(&wg)
+	expectInterp(t, "x, z := 0, 0", env)
+	expectInterp(t, "wg.Add(1)", env)
+	// Arguments are evaluated before the goroutine starts
+	expectInterp(t, "go func(y int) { z = y; wg.Done() }(x + 2)", env)
+	expectInterp(t, "x = 1", env)
+	wg.Wait()
+	expectResult(t, "z", env, 2)
+}
+
+func TestGoStmtPanic(t *testing.T) {
+	env := MakeSimpleEnv()
+	panics := make(chan error, 1)
+	defer func(handler func(error)) { GoPanicHandler = handler }(GoPanicHandler)
+	GoPanicHandler = func(err error) { panics <- err }
+
+	expectInterp(t, "go func() { panic(\"boom\") }()", env)
+	if err := <-panics; err.Error() != "boom" {
+		t.Fatalf("Expected goroutine panic boom, got %v", err)
+	}
+	expectInterp(t, "var s []int", env)
+	expectInterp(t, "go func() { _ = s[1] }()", env)
+	if err, ok := (<-panics).(PanicIndexOutOfBounds); !ok {
+		t.Fatalf("Expected PanicIndexOutOfBounds, got %v", err)
+	}
+}
+
+func TestGoStmtBuiltinArgs(t *testing.T) {
+	env := MakeSimpleEnv()
+	panics := make(chan error, 1)
+	defer func(handler func(error)) { GoPanicHandler = handler }(GoPanicHandler)
+	GoPanicHandler = func(err error) { panics <- err }
+
+	// Arguments of builtins are also evaluated before the goroutine starts
+	expectInterp(t, "x := 1", env)
+	expectInterp(t, "go panic(x)", env)
+	expectInterp(t, "x = 2", env)
+	if err, ok := (<-panics).(PanicUser); !ok || reflect.Value(err).Interface() != 1 {
+		t.Fatalf("Expected goroutine panic 1, got %v", err)
+	}
+}
+
+func TestSelectRecv(t *testing.T) {
+	env := MakeSimpleEnv()
+	a, b := make(chan int, 1), make(chan string, 1)
+	env.Vars["a"] = reflect.ValueOf(&a)
+	env.Vars["b"] = reflect.ValueOf(&b)
+	b <- "hello"
+	expectInterp(t, "x := \"\"", env)
+	expectInterp(t, "select { case v := <-a: x = \"a\"; _ = v; case v, ok := <-b: if ok { x = v } }", env)
+	expectResult(t, "x", env, "hello")
+
+	close(a)
+	expectInterp(t, "y, ok := 1, true", env)
+	expectInterp(t, "select { case y, ok = <-a: }", env)
+	expectResult(t, "y", env, 0)
+	expectResult(t, "ok", env, false)
+}
+
+func TestSelectSend(t *testing.T) {
+	env := MakeSimpleEnv()
+	ch := make(chan int, 1)
+	env.Vars["ch"] = reflect.ValueOf(&ch)
+	expectInterp(t, "x := 0", env)
+	expectInterp(t, "select { case ch <- 3: x = 1; default: x = 2 }", env)
+	expectResult(t, "x", env, 1)
+	expectInterp(t, "select { case ch <- 4: x = 1; default: x = 2 }", env)
+	expectResult(t, "x", env, 2)
+	if v := <-ch; v != 3 {
+		t.Fatalf("Expected 3 sent on ch, got %v", v)
+	}
+}
+
+func TestSelectSendClosed(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "ch := make(chan int, 1)", env)
+	expectInterp(t, "close(ch)", env)
+	if _, panik, errs := Interpret("select { case ch <- 1: default: }", env); errs != nil {
+		t.Fatalf("Failed to check select %v", errs)
+	} else if panik == nil || panik.Error() != "send on closed channel" {
+		t.Fatalf("Expected send on closed channel, got %v", panik)
+	}
+	expectResult(t, "func() (r interface{}) { defer func() { r = recover() }(); select { case ch <- 1: }; return r }() != nil", env, true)
+}
+
+func TestSelectBreak(t *testing.T) {
+	env := MakeSimpleEnv()
+	ch := make(chan int, 1)
+	env.Vars["ch"] = reflect.ValueOf(&ch)
+	expectInterp(t, "x := 0", env)
+	expectInterp(t, "for i := 0; i < 3; i += 1 { select { default: x += 1; break; x = 10 } }", env)
+	expectResult(t, "x", env, 3)
+	expectInterp(t, "x = 0", env)
+	stmt := `loop:
+	for {
+		select {
+		case ch <- x:
+			x += 1
+			continue loop
+		case <-ch:
+			break loop
+		}
+		x = 10
+	}`
+	// The first iteration fills ch, the second drains it
+	expectInterp(t, stmt, env)
+	expectResult(t, "x", env, 1)
+}
+
+func TestSendStmt(t *testing.T) {
+	env := MakeSimpleEnv()
+	ch := make(chan interface{}, 2)
+	env.Vars["ch"] = reflect.ValueOf(&ch)
+	expectInterp(t, "ch <- 1", env)
+	expectInterp(t, "ch <- \"a\"", env)
+	if v := <-ch; v != 1 {
+		t.Fatalf("Expected 1 sent on ch, got %v", v)
+	} else if v := <-ch; v != "a" {
+		t.Fatalf("Expected a sent on ch, got %v", v)
+	}
+	close(ch)
+	if s, err := ParseStmt("ch <- 2"); err != nil {
+		t.Fatalf("Failed to parse stmt (%v)", err)
+	} else if c, errs := CheckStmt(s, env); errs != nil {
+		t.Fatalf("Failed to check stmt (%v)", errs)
+	} else if _, err := InterpStmt(c, env); err == nil || err.Error() != "send on closed channel" {
+		t.Fatalf("Expected send on closed channel, got %v", err)
+	}
+}
+
+func TestRecvBlocks(t *testing.T) {
+	env := MakeSimpleEnv()
+	ch := make(chan int)
+	env.Vars["ch"] = reflect.ValueOf(&ch)
+	expectInterp(t, "go func() { ch <- 5 }()", env)
+	expectResults(t, "<-ch", env, 5, true)
+	go close(ch)
+	expectInterp(t, "v, ok := <-ch", env)
+	expectResult(t, "ok", env, false)
+}
+
+func TestRecvNonBlocking(t *testing.T) {
+	env := MakeSimpleEnv()
+	ch := make(chan int, 1)
+	env.Vars["ch"] = reflect.ValueOf(&ch)
+	defer func(b bool) { NonBlockingRecv = b }(NonBlockingRecv)
+	NonBlockingRecv = true
+	expectInterp(t, "v, ok := <-ch", env)
+	expectResult(t, "ok", env, false)
+	ch <- 3
+	expectInterp(t, "v, ok = <-ch", env)
+	expectResult(t, "v", env, 3)
+	expectResult(t, "ok", env, true)
+}
+
+func TestDeclError(t *testing.T) {
+	env := MakeSimpleEnv()
+	env.Vars["boom"] = reflect.ValueOf(new(error))
+	*env.Vars["boom"].Interface().(*error) = os.ErrNotExist
+	expectInterp(t, "var e error = boom", env)
+	expectResult(t, "e == boom", env, true)
+	expectInterp(t, "f := func(fail bool) error { if fail { return boom }; return nil }", env)
+	expectResult(t, "f(false) == nil", env, true)
+	expectResult(t, "f(true).Error()", env, os.ErrNotExist.Error())
+	expectInterp(t, "var a any = 1", env)
+	expectInterp(t, "var p uintptr = 16", env)
+	expectInterp(t, "p = p | 1", env)
+	expectResult(t, "p", env, uintptr(17))
+}
+
+func TestTypeSwitchError(t *testing.T) {
+	env := MakeSimpleEnv()
+	var i interface{} = os.ErrNotExist
+	env.Vars["i"] = reflect.ValueOf(&i)
+	expectInterp(t, "x := \"\"", env)
+	expectInterp(t, "switch v := i.(type) { case string: x = v; case error: x = v.Error() }", env)
+	expectResult(t, "x", env, os.ErrNotExist.Error())
+}
+
+func TestMethodDecl(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "type Celsius float64", env)
+	env.Funcs["fmt"] = reflect.ValueOf(func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) })
+	expectInterp(t, `func (c Celsius) String() string { return fmt(float64(c)) + "C" }`, env)
+	expectInterp(t, "c := Celsius(21.5)", env)
+	expectResult(t, "c.String()", env, "21.5C")
+	expectInterp(t, "f := c.String", env)
+	expectInterp(t, "c = 0", env)
+	expectResult(t, "f()", env, "21.5C")
+	expectResult(t, "Celsius.String(c)", env, "0C")
+	expectResult(t, "(*Celsius).String(&c)", env, "0C")
+}
+
+func TestMethodDeclPtrReceiver(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "type Counter struct{ n int }", env)
+	expectInterp(t, "func (c *Counter) Inc() { c.n += 1 }", env)
+	expectInterp(t, "func (c *Counter) Add(n int) int { for i := 0; i < n; i++ { c.Inc() }; return c.n }", env)
+	expectInterp(t, "var c Counter", env)
+	expectInterp(t, "c.Inc()", env)
+	expectResult(t, "c.n", env, 1)
+	expectInterp(t, "p := &c", env)
+	expectResult(t, "p.Add(2)", env, 3)
+	expectResult(t, "c.n", env, 3)
+}
+
+func TestMethodDeclRecursive(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "type N int", env)
+	expectInterp(t, "func (n N) Fact() N { if n <= 1 { return 1 }; return n * (n - 1).Fact() }", env)
+	expectResult(t, "int(N(5).Fact())", env, 120)
+}
+
+func TestMethodDeclInterface(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "type Celsius float64", env)
+	expectInterp(t, "type Fahrenheit float64", env)
+	expectInterp(t, `func (Celsius) Unit() string { return "C" }`, env)
+	expectInterp(t, `func (Fahrenheit) Unit() string { return "F" }`, env)
+	expectInterp(t, "var u interface{ Unit() string } = Celsius(1)", env)
+	expectInterp(t, "units := u.Unit()", env)
+	expectInterp(t, "u = Fahrenheit(2)", env)
+	expectInterp(t, "units += u.Unit()", env)
+	expectResult(t, "units", env, "CF")
+
+	expectInterp(t, "var i interface{} = Fahrenheit(2)", env)
+	expectInterp(t, "x := 0", env)
+	expectInterp(t, "switch i.(type) { case float64: x = 1; case Celsius: x = 2; case Fahrenheit: x = 3 }", env)
+	expectResult(t, "x", env, 3)
+	expectInterp(t, "_, ok := i.(Celsius)", env)
+	expectResult(t, "ok", env, false)
+	expectResult(t, "float64(i.(Fahrenheit))", env, 2.0)
+	expectInterp(t, "switch v := i.(type) { case interface{ Unit() string }: x = len(v.Unit()) }", env)
+	expectResult(t, "x", env, 1)
+}
+
+func TestMethodDeclInspect(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, "type Answer int", env)
+	expectInterp(t, `func (Answer) String() string { return "forty-two" }`, env)
+	expectInterp(t, "var a Answer = 42", env)
+	expectInterp(t, "var i interface{} = a", env)
+	if s := InspectTyped(env.Var("a").Elem().Field(0), env.Type("Answer")); s != "forty-two" {
+		t.Fatalf("InspectTyped(a) = %s, expected forty-two", s)
+	}
+	if s := Inspect(env.Var("i").Elem().Elem()); s != "forty-two" {
+		t.Fatalf("Inspect(i) = %s, expected forty-two", s)
+	}
+	if s := Inspect(reflect.ValueOf(42)); s != "42" {
+		t.Fatalf("Inspect(42) = %s, expected 42", s)
+	}
+}
@@ -0,0 +1,379 @@
+package eval
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Named is a named type declared by interpreted code, such as
+// type Celsius float64. reflect cannot create named types or attach methods
+// to types, so values of a Named are stored as values of the underlying
+// type, and the methods declared on it only exist within the interpreter.
+//
+// Variables of a Named type, and values of a Named type stored in
+// interfaces, are wrapped in a distinct box type so that the Named can be
+// recovered at runtime. Boxes are unwrapped when passed to host functions,
+// returned to host callers or assigned to package variables, and host
+// interfaces hold adapters registered by RegisterAdapter instead.
+//
+// The pointer type *T of a Named T is also a Named, sharing the methods of T.
+type Named struct {
+	reflect.Type
+	name string
+
+	// For the pointer type *T, the Named T. Otherwise nil.
+	elem *Named
+	ptr *Named
+
+	// Methods declared on T sorted by name, including those with pointer
+	// receivers. Only the Named T holds methods.
+	methods []*namedMethod
+
+	box reflect.Type
+}
+
+// A method declared by interpreted code. The Type and Func of the method
+// include the receiver as the first argument, as for methods returned by
+// reflect.Type.Method. Func is only valid once the declaration has been
+// interpreted.
+type namedMethod struct {
+	reflect.Method
+	ptrRecv bool
+}
+
+var namedTypes struct {
+	sync.Mutex
+	n int
+
+	// Read without the lock by every variable access
+	boxes sync.Map // map[reflect.Type]*Named
+}
+
+// Create a named type with underlying type t. Each call creates a
+// distinct type, even for identical names.
+func NewNamed(name string, t reflect.Type) *Named {
+	return newNamed(name, unhackType(t), nil)
+}
+
+func newNamed(name string, t reflect.Type, elem *Named) *Named {
+	namedTypes.Lock()
+	defer namedTypes.Unlock()
+	return newNamedLocked(name, t, elem)
+}
+
+func newNamedLocked(name string, t reflect.Type, elem *Named) *Named {
+	named := &Named{Type: t, name: name, elem: elem}
+	tag := fmt.Sprintf("eval:%q", fmt.Sprintf("%s#%d", named.String(), namedTypes.n))
+	named.box = reflect.StructOf([]reflect.StructField{
+		{Name: "V", Type: t, Tag: reflect.StructTag(tag)},
+	})
+	namedTypes.n += 1
+	namedTypes.boxes.Store(named.box, named)
+	return named
+}
+
+func (named *Named) Name() string {
+	return named.name
+}
+
+func (named *Named) String() string {
+	if named.elem != nil {
+		return "*" + named.elem.String()
+	}
+	return named.name
+}
+
+func (named *Named) Elem() reflect.Type {
+	if named.elem != nil {
+		return named.elem
+	}
+	return named.Type.Elem()
+}
+
+// Returns the pointer type *T. Pointers to *T are not Named.
+func (named *Named) pointer() reflect.Type {
+	if named.elem != nil {
+		return reflect.PtrTo(named.Type)
+	}
+	namedTypes.Lock()
+	defer namedTypes.Unlock()
+	if named.ptr == nil {
+		named.ptr = newNamedLocked("", reflect.PtrTo(named.Type), named)
+	}
+	return named.ptr
+}
+
+// The method set of T contains the methods with value receivers, and
+// the method set of *T contains all methods declared on T.
+func (named *Named) methodSet() []*namedMethod {
+	namedTypes.Lock()
+	defer namedTypes.Unlock()
+	if named.elem != nil {
+		return named.elem.methods
+	}
+	var methods []*namedMethod
+	for _, m := range named.methods {
+		if !m.ptrRecv {
+			methods = append(methods, m)
+		}
+	}
+	return methods
+}
+
+func (named *Named) NumMethod() int {
+	return len(named.methodSet())
+}
+
+func (named *Named) Method(i int) reflect.Method {
+	method := named.methodSet()[i].Method
+	method.Index = i
+	return method
+}
+
+func (named *Named) MethodByName(name string) (reflect.Method, bool) {
+	for i, m := range named.methodSet() {
+		if m.Name == name {
+			method := m.Method
+			method.Index = i
+			return method, true
+		}
+	}
+	return reflect.Method{}, false
+}
+
+func (named *Named) Implements(u reflect.Type) bool {
+	return implements(named, u)
+}
+
+func (named *Named) AssignableTo(u reflect.Type) bool {
+	return typeAssignableTo(named, u)
+}
+
+func (named *Named) ConvertibleTo(u reflect.Type) bool {
+	return typeConvertibleTo(named, u)
+}
+
+// Returns the method name declared on T or *T, which need not be in the
+// method set of named.
+func (named *Named) lookupMethod(name string) *namedMethod {
+	base := named
+	if named.elem != nil {
+		base = named.elem
+	}
+	namedTypes.Lock()
+	defer namedTypes.Unlock()
+	for _, m := range base.methods {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// Declare a method on T, where ft is the type of the method including the
+// receiver. Returns nil if the method is already declared.
+func (named *Named) addMethod(name string, ft reflect.Type, ptrRecv bool) *namedMethod {
+	if named.lookupMethod(name) != nil {
+		return nil
+	}
+	namedTypes.Lock()
+	defer namedTypes.Unlock()
+	m := &namedMethod{Method: reflect.Method{Name: name, Type: ft}, ptrRecv: ptrRecv}
+	named.methods = append(named.methods, m)
+	sort.Sort(namedMethodsByName(named.methods))
+	return m
+}
+
+func (named *Named) removeMethod(m *namedMethod) {
+	namedTypes.Lock()
+	defer namedTypes.Unlock()
+	for i, other := range named.methods {
+		if other == m {
+			named.methods = append(named.methods[:i], named.methods[i+1:]...)
+			return
+		}
+	}
+}
+
+func (m *namedMethod) setFunc(f reflect.Value) {
+	namedTypes.Lock()
+	defer namedTypes.Unlock()
+	m.Func = f
+}
+
+func (m *namedMethod) fun() reflect.Value {
+	namedTypes.Lock()
+	defer namedTypes.Unlock()
+	return m.Func
+}
+
+// The signature of the method, excluding the receiver.
+func (m *namedMethod) signature() reflect.Type {
+	ft := m.Type
+	in := make([]reflect.Type, ft.NumIn() - 1)
+	for i := range in {
+		in[i] = ft.In(i + 1)
+	}
+	out := make([]reflect.Type, ft.NumOut())
+	for i := range out {
+		out[i] = ft.Out(i)
+	}
+	return reflect.FuncOf(in, out, ft.IsVariadic())
+}
+
+// Adjust recv, a value of T or *T, to the receiver type of the method.
+// Value receivers are copied.
+func (m *namedMethod) receiver(recv reflect.Value) reflect.Value {
+	if m.ptrRecv {
+		if recv.Kind() != reflect.Ptr || recv.Type() != m.Type.In(0) {
+			recv = recv.Addr()
+		}
+		return recv
+	}
+	if recv.Type() != m.Type.In(0) {
+		if recv.IsNil() {
+			panic(PanicInvalidDereference{})
+		}
+		recv = recv.Elem()
+	}
+	v := reflect.New(recv.Type()).Elem()
+	v.Set(recv)
+	return v
+}
+
+// Returns the method value of m bound to recv.
+func (m *namedMethod) bind(recv reflect.Value) reflect.Value {
+	recv = m.receiver(recv)
+	return newInterpFunc(m.signature(), func(in []reflect.Value, deferredBy *funcFrame) []reflect.Value {
+		args := append([]reflect.Value{recv}, in...)
+		return forwardCall(m.fun(), args, deferredBy)
+	})
+}
+
+// Returns the method expression of m with receiver type recvT.
+func (m *namedMethod) expr(recvT reflect.Type) reflect.Value {
+	ft := methodExprType(recvT, m.signature())
+	return newInterpFunc(ft, func(in []reflect.Value, deferredBy *funcFrame) []reflect.Value {
+		args := append([]reflect.Value{m.receiver(in[0])}, in[1:]...)
+		return forwardCall(m.fun(), args, deferredBy)
+	})
+}
+
+type namedMethodsByName []*namedMethod
+
+func (m namedMethodsByName) Len() int           { return len(m) }
+func (m namedMethodsByName) Less(i, j int) bool { return m[i].Name < m[j].Name }
+func (m namedMethodsByName) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+
+// Returns the pointer type to t, which is a Named if t is a Named.
+func ptrTo(t reflect.Type) reflect.Type {
+	if named, ok := t.(*Named); ok {
+		return named.pointer()
+	}
+	return reflect.PtrTo(unhackType(t))
+}
+
+// Wrap v, a value of type t, in a box if t is a Named, so that the Named
+// can be recovered once v is stored in an interface.
+func boxNamed(v reflect.Value, t reflect.Type) reflect.Value {
+	if named, ok := t.(*Named); ok && v.Type() == named.Type {
+		box := reflect.New(named.box).Elem()
+		box.Field(0).Set(v)
+		return box
+	}
+	return v
+}
+
+// Convert v, a value passed to host code expecting type to, to the plain
+// value of its Named type. Boxes only exist within the interpreter, so host
+// code sees the underlying value, or an adapter if to is interface{} and an
+// adapter applies.
+func hostValue(v reflect.Value, to reflect.Type) reflect.Value {
+	if to.Kind() != reflect.Interface {
+		return v
+	} else if unboxed, named := unboxNamed(v); named != nil {
+		r := reflect.New(unhackType(to)).Elem()
+		if to.NumMethod() == 0 {
+			r.Set(emptyInterfaceAdapter(unboxed, named))
+		} else {
+			r.Set(unboxed)
+		}
+		return r
+	}
+	return v
+}
+
+// Returns the value boxed by v, or by the dynamic value of the interface
+// value v, and its Named, or a nil Named if v held no box.
+func unboxNamed(v reflect.Value) (reflect.Value, *Named) {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		if named := boxedNamed(v.Type()); named != nil {
+			return v.Field(0), named
+		}
+	}
+	return v, nil
+}
+
+// Convert args, the arguments of a call to the host func type ft, with
+// hostValue. If spread, the variadic arguments are passed as a slice, which
+// is copied if any of its elements is boxed.
+func hostArgs(ft reflect.Type, args []reflect.Value, spread bool) []reflect.Value {
+	out := make([]reflect.Value, len(args))
+	n := ft.NumIn()
+	for i, arg := range args {
+		if !ft.IsVariadic() || i < n - 1 {
+			out[i] = hostValue(arg, ft.In(i))
+		} else if !spread {
+			out[i] = hostValue(arg, ft.In(n - 1).Elem())
+		} else {
+			out[i] = hostSlice(arg)
+		}
+	}
+	return out
+}
+
+func hostSlice(s reflect.Value) reflect.Value {
+	elemT := s.Type().Elem()
+	if elemT.Kind() != reflect.Interface {
+		return s
+	}
+	for i := 0; i < s.Len(); i += 1 {
+		if _, named := unboxNamed(s.Index(i)); named != nil {
+			copied := reflect.MakeSlice(s.Type(), s.Len(), s.Len())
+			for j := 0; j < s.Len(); j += 1 {
+				copied.Index(j).Set(hostValue(s.Index(j), elemT))
+			}
+			return copied
+		}
+	}
+	return s
+}
+
+// Returns the dynamic value and type of an interface value, unwrapping
+// boxed and adapted values of Named types.
+func dynamicValue(v reflect.Value) (reflect.Value, reflect.Type) {
+	dynamic := v.Elem()
+	if !dynamic.IsValid() {
+		return dynamic, nil
+	} else if named := boxedNamed(dynamic.Type()); named != nil {
+		return dynamic.Field(0), named
+	} else if w, named := adaptedBy(dynamic); named != nil {
+		return w, named
+	}
+	return dynamic, dynamic.Type()
+}
+
+func boxedNamed(t reflect.Type) *Named {
+	if t.Kind() != reflect.Struct || t.NumField() != 1 {
+		return nil
+	}
+	if named, ok := namedTypes.boxes.Load(t); ok {
+		return named.(*Named)
+	}
+	return nil
+}
@@ -2,9 +2,17 @@ package eval
 
 import (
 	"fmt"
+	"os"
 	"reflect"
 )
 
+// GoPanicHandler is called with the panic of a goroutine started by an
+// interpreted go statement. Without it, such a panic would crash the host
+// process. The default prints the panic to stderr; set to nil to ignore.
+var GoPanicHandler = func(err error) {
+	fmt.Fprintf(os.Stderr, "panic in goroutine: %v\n", err)
+}
+
 type PanicUser reflect.Value
 type PanicDivideByZero struct {}
 type PanicInvalidDereference struct {}
@@ -20,6 +28,7 @@ type PanicInterfaceConversion struct {
 	// the dynamic type of operand. nil for interface to interface assertions
 	dynamicT reflect.Type
 }
+type PanicCloseNilChan struct {}
 type PanicUncomparableType struct {
 	dynamicT reflect.Type
 }
@@ -27,10 +36,22 @@ type PanicUnhashableType struct {
 	dynamicT reflect.Type
 }
 
+// Convert a recovered host panic to an error.
+func panicError(r interface{}) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return PanicUser(reflect.ValueOf(r))
+}
+
 func (p PanicUser) Error() string {
 	return fmt.Sprint(reflect.Value(p).Interface())
 }
 
+func (err PanicCloseNilChan) Error() string {
+	return "close of nil channel"
+}
+
 func (err PanicDivideByZero) Error() string {
         return "runtime error: integer divide by zero"
 }
@@ -51,17 +72,8 @@ func (err PanicInterfaceConversion) Error() string {
 	if err.xT == nil {
 		return fmt.Sprintf("interface conversion: nil is not %v", err.aT)
 	} else if err.dynamicT == nil {
-		var missingMethod string
-		numMethod := err.aT.NumMethod()
-		for i := 0; i < numMethod; i += 1 {
-			missingMethod = err.aT.Method(i).Name
-			if _, ok := err.xT.MethodByName(missingMethod); !ok {
-				break
-			}
-		}
-
 		return fmt.Sprintf("interface conversion: %v is not %v: missing method %s",
-			err.xT, err.aT, missingMethod)
+			err.xT, err.aT, missingMethod(err.aT, err.xT))
 	} else {
 		return fmt.Sprintf("interface conversion: %v is %v, not %v",
 			err.xT, err.dynamicT, err.aT)
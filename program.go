@@ -0,0 +1,586 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+)
+
+// A Program is a checked package main, consisting of one or more source
+// files with top-level declarations in any order, init funcs and a main.
+type Program struct {
+	// The universe scope of the program, holding the imported packages
+	root Env
+
+	// Type and const specs, in the order they were successfully checked
+	specs []Spec
+
+	// Funcs other than init, including main
+	funcs []*FuncDecl
+	methods []*FuncDecl
+
+	// Var specs in initialization order
+	vars []Spec
+
+	inits []*FuncLit
+}
+
+// Parse and run the package main consisting of the source files filenames.
+// Imports are resolved through the Registry of env.
+func RunFiles(filenames []string, env Env) (panik error, compileErrors []error) {
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(filenames))
+	for _, filename := range filenames {
+		file, err := parser.ParseFile(fset, filename, nil, 0)
+		if err != nil {
+			compileErrors = append(compileErrors, parseErrors(err)...)
+			continue
+		}
+		files = append(files, file)
+	}
+	if compileErrors != nil {
+		return nil, compileErrors
+	}
+	return runFiles(files, env)
+}
+
+// As RunFiles, for a single source file given as a string.
+func RunSource(src string, env Env) (panik error, compileErrors []error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+	if err != nil {
+		return nil, parseErrors(err)
+	}
+	return runFiles([]*ast.File{file}, env)
+}
+
+func runFiles(files []*ast.File, env Env) (panik error, compileErrors []error) {
+	if prog, errs := CheckProgram(files, env); errs != nil {
+		return nil, errs
+	} else {
+		return prog.Run(), nil
+	}
+}
+
+func parseErrors(err error) []error {
+	var errs []error
+	if list, ok := err.(scanner.ErrorList); ok {
+		for i := range list {
+			errs = append(errs, list[i])
+		}
+	} else {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Type check the files of a package main. Imports are resolved through the
+// Registry of env.
+//
+// Types and consts are checked first, retrying those which refer to later
+// declarations until no more can be checked. The signatures of funcs and
+// methods follow, then vars in initialization order, and finally the
+// bodies of funcs and methods, which may refer to any top-level declaration.
+func CheckProgram(files []*ast.File, env Env) (*Program, []error) {
+	prog := &Program{root: MakeSimpleEnv()}
+	errs := prog.checkImports(files, env)
+
+	var gens []*ast.GenDecl
+	var varSpecs []*ast.ValueSpec
+	var funcs []*ast.FuncDecl
+	declared := map[string]bool{}
+	declare := func(ident *ast.Ident) {
+		if ident.Name == "_" {
+			return
+		} else if declared[ident.Name] {
+			errs = append(errs, ErrRedeclared{&Ident{Ident: ident}})
+		}
+		declared[ident.Name] = true
+	}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil && decl.Name.Name != "init" {
+					declare(decl.Name)
+				}
+				funcs = append(funcs, decl)
+			case *ast.GenDecl:
+				switch decl.Tok {
+				case token.CONST:
+					gens = append(gens, decl)
+				case token.TYPE:
+					// Type specs are checked individually, as they may
+					// refer to later specs of the same declaration
+					for _, spec := range decl.Specs {
+						gens = append(gens, &ast.GenDecl{Tok: token.TYPE, Specs: []ast.Spec{spec}})
+					}
+				case token.VAR:
+					for _, spec := range decl.Specs {
+						varSpecs = append(varSpecs, spec.(*ast.ValueSpec))
+					}
+				}
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						declare(spec.Name)
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							declare(name)
+						}
+					}
+				}
+			}
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	env = prog.root.PushScope()
+	errs = prog.checkTypesAndConsts(gens, env)
+
+	var bodies []func() []error
+	hasMain := false
+	for _, decl := range funcs {
+		if decl.Recv != nil {
+			adecl, ft, moreErrs := checkMethodSignature(decl, env)
+			if adecl.Lit == nil {
+				errs = append(errs, moreErrs...)
+				continue
+			}
+			bodies = append(bodies, func() []error {
+				_, moreErrs := checkMethodBody(adecl, ft, moreErrs, env)
+				return moreErrs
+			})
+			prog.methods = append(prog.methods, adecl)
+			continue
+		} else if decl.Body == nil {
+			errs = append(errs, errors.New("missing function body"))
+			continue
+		}
+
+		lit := &ast.FuncLit{Type: decl.Type, Body: decl.Body}
+		alit, ft, moreErrs := checkFuncLitType(lit, env)
+		adecl := &FuncDecl{FuncDecl: decl, Lit: alit}
+		name := decl.Name.Name
+		if name == "init" || name == "main" {
+			if decl.Type.Params.NumFields() != 0 || decl.Type.Results.NumFields() != 0 {
+				moreErrs = append(moreErrs, fmt.Errorf("func %s must have no arguments and no return values", name))
+			}
+			hasMain = hasMain || name == "main"
+		}
+		init := len(prog.inits)
+		if name == "init" {
+			prog.inits = append(prog.inits, alit)
+		} else {
+			if name != "_" && ft != nil {
+				// Only the type of the func is needed during checking
+				env.AddFunc(name, reflect.Zero(ft))
+			}
+			prog.funcs = append(prog.funcs, adecl)
+		}
+		bodies = append(bodies, func() []error {
+			alit, moreErrs := checkFuncLitBody(alit, ft, moreErrs, env)
+			adecl.Lit = alit
+			if name == "init" {
+				prog.inits[init] = alit
+			}
+			return moreErrs
+		})
+	}
+	if !hasMain {
+		errs = append(errs, errors.New("function main is undeclared in the main package"))
+	}
+
+	ordered, cycleErrs := initOrder(varSpecs, funcs)
+	for _, spec := range ordered {
+		gen := &ast.GenDecl{Tok: token.VAR, Specs: []ast.Spec{spec}}
+		decl, moreErrs := checkDeclStmt(&ast.DeclStmt{Decl: gen}, env)
+		errs = append(errs, moreErrs...)
+		prog.vars = append(prog.vars, decl.Specs...)
+	}
+	if cycleErrs != nil {
+		// Bodies may refer to the vars which could not be checked
+		return nil, append(errs, cycleErrs...)
+	}
+	for _, body := range bodies {
+		errs = append(errs, body()...)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return prog, nil
+}
+
+// Bind the packages imported by files in the universe scope of prog.
+func (prog *Program) checkImports(files []*ast.File, env Env) []error {
+	var errs []error
+	imported := map[string]string{}
+	for _, file := range files {
+		if file.Name.Name != "main" {
+			errs = append(errs, fmt.Errorf("package %s; expected main", file.Name.Name))
+			continue
+		}
+		for _, spec := range file.Imports {
+			aspec, moreErrs := checkImportSpec(spec, env)
+			if moreErrs != nil {
+				errs = append(errs, moreErrs...)
+				continue
+			} else if other, ok := imported[aspec.name]; ok && other != aspec.path && aspec.name != "." && aspec.name != "_" {
+				errs = append(errs, fmt.Errorf("%s redeclared as imported package name", aspec.name))
+				continue
+			}
+			imported[aspec.name] = aspec.path
+			interpImportSpec(aspec, prog.root)
+		}
+	}
+	return errs
+}
+
+// Check type and const declarations, which may appear in any order.
+// Declarations which fail to check are retried while others succeed, as
+// they may refer to declarations which have not yet been checked.
+func (prog *Program) checkTypesAndConsts(gens []*ast.GenDecl, env Env) []error {
+	for len(gens) > 0 {
+		var failed []*ast.GenDecl
+		var errs [][]error
+		for _, gen := range gens {
+			decl, moreErrs := checkDeclStmt(&ast.DeclStmt{Decl: gen}, env)
+			if moreErrs != nil {
+				failed = append(failed, gen)
+				errs = append(errs, moreErrs)
+			} else {
+				prog.specs = append(prog.specs, decl.Specs...)
+			}
+		}
+		if len(failed) == len(gens) {
+			return declCycleErrors(failed, errs)
+		}
+		gens = failed
+	}
+	return nil
+}
+
+// Returns the errors of the type and const declarations gens, none of which
+// could be checked. Declarations which refer to themselves are reported as
+// cycles. References to other declarations of gens are not reported as
+// undefined, as the errors of those declarations are reported instead.
+func declCycleErrors(gens []*ast.GenDecl, errs [][]error) []error {
+	var specs []ast.Spec
+	var values [][]ast.Expr
+	specOf := map[string]int{}
+	for _, gen := range gens {
+		var last []ast.Expr
+		for _, spec := range gen.Specs {
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				specOf[spec.Name.Name] = len(specs)
+				values = append(values, nil)
+			case *ast.ValueSpec:
+				for _, name := range spec.Names {
+					specOf[name.Name] = len(specs)
+				}
+				// Consts without values repeat those of the previous spec
+				if spec.Values != nil {
+					last = spec.Values
+				}
+				values = append(values, last)
+			}
+			specs = append(specs, spec)
+		}
+	}
+
+	// References between specs, and those not through a pointer, slice,
+	// map, chan, func or interface type
+	refs := make([][]int, len(specs))
+	direct := make([][]int, len(specs))
+	for i, spec := range specs {
+		var visit func(node ast.Node, indirect bool)
+		visit = func(node ast.Node, indirect bool) {
+			ast.Inspect(node, func(node ast.Node) bool {
+				switch node := node.(type) {
+				case *ast.Ident:
+					if j, ok := specOf[node.Name]; !ok {
+						return false
+					} else if node.Obj != nil && node.Obj.Decl != specs[j] {
+						// Resolved to another declaration of the same name
+						return false
+					} else {
+						refs[i] = append(refs[i], j)
+						if !indirect {
+							direct[i] = append(direct[i], j)
+						}
+					}
+				case *ast.Field:
+					visit(node.Type, indirect)
+					return false
+				case *ast.SelectorExpr:
+					visit(node.X, indirect)
+					return false
+				case *ast.StarExpr, *ast.MapType, *ast.ChanType, *ast.FuncType, *ast.InterfaceType:
+					if !indirect {
+						visit(node, true)
+						return false
+					}
+				case *ast.ArrayType:
+					if node.Len == nil && !indirect {
+						visit(node, true)
+						return false
+					}
+				}
+				return true
+			})
+		}
+		switch spec := spec.(type) {
+		case *ast.TypeSpec:
+			visit(spec.Type, false)
+		case *ast.ValueSpec:
+			if spec.Type != nil {
+				visit(spec.Type, false)
+			}
+			for _, value := range values[i] {
+				visit(value, false)
+			}
+		}
+	}
+
+	var cycles []error
+	inCycle := make([]bool, len(specs))
+	for i, spec := range specs {
+		if inCycle[i] {
+			continue
+		}
+		cycle, unsupported := findCycle(i, direct), false
+		if cycle == nil {
+			cycle, unsupported = findCycle(i, refs), true
+		}
+		if cycle == nil {
+			continue
+		}
+		var names []string
+		for _, j := range cycle {
+			inCycle[j] = true
+			names = append(names, specName(specs[j]))
+		}
+		if spec, ok := spec.(*ast.TypeSpec); ok {
+			cycles = append(cycles, ErrRecursiveType{&Ident{Ident: spec.Name}, unsupported})
+		} else {
+			cycles = append(cycles, ErrInitCycle{names})
+		}
+	}
+
+	for _, moreErrs := range errs {
+		for _, err := range moreErrs {
+			if undefined, ok := err.(ErrUndefined); ok {
+				if ident, ok := undefined.Expr.(*Ident); ok {
+					if _, failed := specOf[ident.Name]; failed {
+						continue
+					}
+				}
+			}
+			cycles = append(cycles, err)
+		}
+	}
+	return cycles
+}
+
+// Returns the specs of the shortest cycle from i back to itself through
+// refs, starting with i, or nil if there is none.
+func findCycle(i int, refs [][]int) []int {
+	prev := map[int]int{}
+	queue := []int{i}
+	for len(queue) > 0 {
+		j := queue[0]
+		queue = queue[1:]
+		for _, k := range refs[j] {
+			if k == i {
+				var path []int
+				for ; j != i; j = prev[j] {
+					path = append([]int{j}, path...)
+				}
+				return append([]int{i}, path...)
+			} else if _, ok := prev[k]; !ok {
+				prev[k] = j
+				queue = append(queue, k)
+			}
+		}
+	}
+	return nil
+}
+
+func specName(spec ast.Spec) string {
+	switch spec := spec.(type) {
+	case *ast.TypeSpec:
+		return spec.Name.Name
+	case *ast.ValueSpec:
+		return spec.Names[0].Name
+	}
+	return ""
+}
+
+// Returns specs in initialization order, where a var is initialized after
+// the vars its initializer refers to, directly or through the bodies of
+// funcs and methods. Vars which refer to themselves are reported as
+// initialization cycles, and are omitted along with the vars which refer
+// to them. References resolved by the parser are followed to their
+// declarations, others are found by name, so a field or method of the
+// same name as a var or func may introduce a spurious dependency.
+// Otherwise vars are initialized in declaration order.
+func initOrder(specs []*ast.ValueSpec, funcs []*ast.FuncDecl) ([]*ast.ValueSpec, []error) {
+	specOf := map[string]*ast.ValueSpec{}
+	for _, spec := range specs {
+		for _, name := range spec.Names {
+			specOf[name.Name] = spec
+		}
+	}
+	funcsOf := map[string][]*ast.FuncDecl{}
+	for _, decl := range funcs {
+		if decl.Body != nil && decl.Name.Name != "init" {
+			funcsOf[decl.Name.Name] = append(funcsOf[decl.Name.Name], decl)
+		}
+	}
+
+	// Returns the spec and funcs ident refers to
+	refersTo := func(ident *ast.Ident) (*ast.ValueSpec, []*ast.FuncDecl) {
+		if ident.Obj == nil {
+			return specOf[ident.Name], funcsOf[ident.Name]
+		}
+		switch decl := ident.Obj.Decl.(type) {
+		case *ast.ValueSpec:
+			if specOf[ident.Name] == decl {
+				return decl, nil
+			}
+		case *ast.FuncDecl:
+			if decl.Body != nil {
+				return nil, []*ast.FuncDecl{decl}
+			}
+		}
+		return nil, nil
+	}
+
+	// Returns the specs referred to by node
+	deps := func(node ast.Node) []*ast.ValueSpec {
+		var deps []*ast.ValueSpec
+		seenSpecs := map[*ast.ValueSpec]bool{}
+		seenFuncs := map[*ast.FuncDecl]bool{}
+		var visit func(node ast.Node) bool
+		visit = func(node ast.Node) bool {
+			if ident, ok := node.(*ast.Ident); ok {
+				spec, decls := refersTo(ident)
+				if spec != nil && !seenSpecs[spec] {
+					seenSpecs[spec] = true
+					deps = append(deps, spec)
+				}
+				for _, decl := range decls {
+					if !seenFuncs[decl] {
+						seenFuncs[decl] = true
+						ast.Inspect(decl.Body, visit)
+					}
+				}
+			}
+			return true
+		}
+		ast.Inspect(node, visit)
+		return deps
+	}
+
+	var ordered []*ast.ValueSpec
+	var errs []error
+	const visiting, visited, failed = 1, 2, 3
+	state := map[*ast.ValueSpec]int{}
+	var path []*ast.ValueSpec
+	var visit func(spec *ast.ValueSpec)
+	visit = func(spec *ast.ValueSpec) {
+		if state[spec] != 0 {
+			return
+		}
+		state[spec] = visiting
+		path = append(path, spec)
+		for _, value := range spec.Values {
+			for _, dep := range deps(value) {
+				if dep == spec && len(spec.Names) > 1 {
+					// var x, y = 1, x
+					continue
+				} else if state[dep] == visiting {
+					var names []string
+					i := len(path) - 1
+					for path[i] != dep {
+						i -= 1
+					}
+					for _, s := range path[i:] {
+						names = append(names, s.Names[0].Name)
+						state[s] = failed
+					}
+					errs = append(errs, ErrInitCycle{names})
+				}
+				visit(dep)
+				if state[dep] == failed {
+					state[spec] = failed
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		if state[spec] != failed {
+			state[spec] = visited
+			ordered = append(ordered, spec)
+		}
+	}
+	for _, spec := range specs {
+		visit(spec)
+	}
+	return ordered, errs
+}
+
+// Run the program, initializing its vars and calling its init funcs
+// followed by main. Returns the first panic, if any.
+func (prog *Program) Run() error {
+	env := prog.root.PushScope()
+	for _, spec := range prog.specs {
+		if err := interpSpec(spec, env); err != nil {
+			return err
+		}
+	}
+	var main reflect.Value
+	for _, decl := range prog.funcs {
+		f, _ := evalFuncLit(decl.Lit, env)
+		if name := decl.Name.Name; name != "_" {
+			env.AddFunc(name, f)
+		}
+		if decl.Name.Name == "main" {
+			main = f
+		}
+	}
+	for _, decl := range prog.methods {
+		if decl.method != nil {
+			f, _ := evalFuncLit(decl.Lit, env)
+			decl.method.setFunc(f)
+		}
+	}
+	for _, spec := range prog.vars {
+		if err := callProgramFunc(func() error { return interpSpec(spec, env) }); err != nil {
+			return err
+		}
+	}
+	for _, init := range prog.inits {
+		f, _ := evalFuncLit(init, env)
+		if err := callProgramFunc(func() error { f.Call(nil); return nil }); err != nil {
+			return err
+		}
+	}
+	return callProgramFunc(func() error { main.Call(nil); return nil })
+}
+
+// Call f, returning a panic of the call as an error.
+func callProgramFunc(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError(r)
+		}
+	}()
+	return f()
+}
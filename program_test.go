@@ -0,0 +1,202 @@
+package eval
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Returns an env providing the package "log", whose Print appends to out.
+func makeProgramEnv(out *[]string) *SimpleEnv {
+	env := MakeSimpleEnv()
+	log := MakeSimpleEnv()
+	log.Funcs["Print"] = reflect.ValueOf(func(a ...interface{}) {
+		*out = append(*out, fmt.Sprint(a...))
+	})
+	env.Pkgs["example.com/log"] = log
+	return env
+}
+
+func expectRun(t *testing.T, src string, expected ...string) {
+	var out []string
+	if panik, errs := RunSource(src, makeProgramEnv(&out)); errs != nil {
+		t.Fatalf("Failed to check program %v", errs)
+	} else if panik != nil {
+		t.Fatalf("Program panicked: %v", panik)
+	} else if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Wrong output %q, expected %q", out, expected)
+	}
+}
+
+func expectProgramErrors(t *testing.T, src string, expected ...string) {
+	var out []string
+	_, errs := RunSource(src, makeProgramEnv(&out))
+	var actual []string
+	for _, err := range errs {
+		actual = append(actual, err.Error())
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Wrong errors %q, expected %q", actual, expected)
+	}
+}
+
+func TestRunProgram(t *testing.T) {
+	expectRun(t, `
+package main
+
+import "example.com/log"
+
+func main() {
+	log.Print(greeting, " ", world)
+	log.Print(fib(10))
+}
+
+var greeting = "hello"
+
+const world = "world"
+
+func fib(n int) int {
+	if n < 2 {
+		return n
+	}
+	return fib(n - 1) + fib(n - 2)
+}
+`, "hello world", "55")
+}
+
+func TestRunProgramDeclOrder(t *testing.T) {
+	expectRun(t, `
+package main
+
+import l "example.com/log"
+
+var days = [NumDays]Weekday{}
+
+type Weekday int
+
+func (d Weekday) String() string {
+	return names[d]
+}
+
+const (
+	Sunday Weekday = iota
+	Monday
+	NumDays
+)
+
+var names = map[Weekday]string{Sunday: "Sunday", Monday: "Monday"}
+
+func main() {
+	l.Print(len(days), " ", Monday.String())
+}
+`, "2 Monday")
+}
+
+func TestRunProgramInitOrder(t *testing.T) {
+	expectRun(t, `
+package main
+
+import "example.com/log"
+
+var a = b + 1
+var b = f()
+var c = 3
+
+func f() int {
+	return c * 2
+}
+
+func init() {
+	log.Print("init ", a)
+}
+
+func init() {
+	a += 1
+}
+
+func main() {
+	log.Print("main ", a, b, c)
+}
+`, "init 7", "main 8 6 3")
+}
+
+func TestRunProgramPanic(t *testing.T) {
+	var out []string
+	panik, errs := RunSource(`
+package main
+
+var x []int
+
+func main() {
+	_ = x[1]
+}
+`, makeProgramEnv(&out))
+	if errs != nil {
+		t.Fatalf("Failed to check program %v", errs)
+	} else if panik == nil {
+		t.Fatalf("Expected panic")
+	}
+}
+
+func TestRunFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.go": "package main\nimport \"example.com/log\"\nfunc main() { log.Print(message()) }\n",
+		"b.go": "package main\nfunc message() string { return text }\nvar text = \"from b\"\n",
+	}
+	var filenames []string
+	for name, src := range files {
+		filename := filepath.Join(dir, name)
+		if err := os.WriteFile(filename, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+		filenames = append(filenames, filename)
+	}
+
+	var out []string
+	if panik, errs := RunFiles(filenames, makeProgramEnv(&out)); errs != nil || panik != nil {
+		t.Fatalf("Failed to run files %v %v", errs, panik)
+	} else if strings.Join(out, "\n") != "from b" {
+		t.Fatalf("Wrong output %q", out)
+	}
+}
+
+func TestCheckProgramErrors(t *testing.T) {
+	expectProgramErrors(t, "package foo", "package foo; expected main")
+	expectProgramErrors(t, "package main", "function main is undeclared in the main package")
+	expectProgramErrors(t, "package main\nimport \"fmt\"\nfunc main() {}", "could not import fmt (no package registered with this import path)")
+	expectProgramErrors(t, "package main\nvar x int\nfunc x() {}\nfunc main() {}", "x redeclared in this block")
+	expectProgramErrors(t, "package main\nfunc main() int { return 1 }",
+		"func main must have no arguments and no return values")
+	expectProgramErrors(t, "package main\ntype T U\nfunc main() {}", "undefined: U")
+	expectProgramErrors(t, "package main\nfunc main() { f() }", "undefined: f")
+}
+
+func TestCheckProgramCycles(t *testing.T) {
+	expectProgramErrors(t, "package main\ntype L struct{ next *L }\nfunc main() {}",
+		"invalid recursive type L (not supported by the interpreter)")
+	expectProgramErrors(t, "package main\ntype A struct{ b *B }\ntype B struct{ a *A }\nfunc main() {}",
+		"invalid recursive type A (not supported by the interpreter)")
+	expectProgramErrors(t, "package main\ntype T [2]T\nfunc main() {}", "invalid recursive type T")
+	// Only the root cause is reported for declarations referring to L
+	expectProgramErrors(t, "package main\ntype C struct{ l *L; x U }\ntype L struct{ next *L }\nfunc main() {}",
+		"invalid recursive type L (not supported by the interpreter)", "undefined: U")
+
+	expectProgramErrors(t, "package main\nvar a = b\nvar b = a\nfunc main() {}",
+		"initialization cycle: a refers to b, b refers to a")
+	expectProgramErrors(t, "package main\nvar a = f()\nfunc f() int { return a }\nfunc main() {}",
+		"initialization cycle: a refers to itself")
+	expectProgramErrors(t, "package main\nconst c = d\nconst d = c\nfunc main() {}",
+		"initialization cycle: c refers to d, d refers to c")
+
+	// Locals of the same name are not references to the var
+	expectRun(t, `package main
+import "example.com/log"
+var x = f()
+func f() int { x := 1; return x }
+func main() { log.Print(x) }
+`, "1")
+}
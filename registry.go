@@ -0,0 +1,163 @@
+package eval
+
+import (
+	"fmt"
+	"go/ast"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A Registry maps import paths to package Envs, from which import
+// declarations are resolved. Imports in a SimpleEnv are resolved through
+// the Registry of its root scope, or DefaultRegistry if it has none.
+type Registry struct {
+	mu sync.RWMutex
+	pkgs map[string]Env
+}
+
+// The registry used for imports in envs without a registry of their own.
+var DefaultRegistry = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{pkgs: map[string]Env{}}
+}
+
+// Register pkg as the package with import path path, replacing any package
+// previously registered with that path. The Path of a *SimpleEnv is set to
+// path if empty.
+func (r *Registry) Register(path string, pkg Env) {
+	if env, ok := pkg.(*SimpleEnv); ok && env.Path == "" {
+		env.Path = path
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pkgs[path] = pkg
+}
+
+// Returns the package with import path path, or nil if none is registered.
+func (r *Registry) Lookup(path string) Env {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.pkgs[path]
+}
+
+// Register pkg with DefaultRegistry.
+func RegisterPackage(path string, pkg Env) {
+	DefaultRegistry.Register(path, pkg)
+}
+
+// Returns the root scope of env as a *SimpleEnv, or nil if it is not one.
+func rootSimpleEnv(env Env) *SimpleEnv {
+	root := env
+	for ; env != nil; env = env.PopScope() {
+		root = env
+	}
+	if fenv, ok := root.(frameEnv); ok {
+		root = fenv.Env
+	}
+	senv, _ := root.(*SimpleEnv)
+	return senv
+}
+
+// Returns the registry used to resolve imports in env.
+func envRegistry(env Env) *Registry {
+	if root := rootSimpleEnv(env); root != nil && root.Registry != nil {
+		return root.Registry
+	}
+	return DefaultRegistry
+}
+
+// Returns the package imported by the import path path in env. Packages
+// added to the root scope of env, either under their import path or with
+// their Path set, are also found.
+func importPackage(path string, env Env) Env {
+	if pkg := envRegistry(env).Lookup(path); pkg != nil {
+		return pkg
+	} else if pkg := env.Pkg(path); pkg != nil {
+		return pkg
+	} else if root := rootSimpleEnv(env); root != nil {
+		root.mu.RLock()
+		defer root.mu.RUnlock()
+		for _, pkg := range root.Pkgs {
+			if senv, ok := pkg.(*SimpleEnv); ok && senv.Path == path {
+				return pkg
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the default name of the package with import path path, its last
+// element excluding any version suffix, such as yaml for gopkg.in/yaml.v2
+// or foo for example.com/foo/v2.
+func importName(path string) string {
+	elems := strings.Split(path, "/")
+	name := elems[len(elems) - 1]
+	if len(elems) > 1 && len(name) > 1 && name[0] == 'v' {
+		if _, err := strconv.Atoi(name[1:]); err == nil {
+			name = elems[len(elems) - 2]
+		}
+	}
+	if i := strings.Index(name, "."); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+func checkImportSpec(spec *ast.ImportSpec, env Env) (*ImportSpec, []error) {
+	aspec := &ImportSpec{ImportSpec: spec}
+	path, err := strconv.Unquote(spec.Path.Value)
+	if err != nil || path == "" {
+		return aspec, []error{ErrInvalidImportPath{spec.Path.Value}}
+	}
+	aspec.path = path
+	if aspec.pkg = importPackage(path, env); aspec.pkg == nil {
+		return aspec, []error{ErrUnregisteredImport{path}}
+	}
+	if spec.Name != nil {
+		aspec.name = spec.Name.Name
+	} else {
+		aspec.name = importName(path)
+	}
+	if aspec.name == "." {
+		if _, ok := aspec.pkg.(*SimpleEnv); !ok {
+			return aspec, []error{fmt.Errorf("cannot dot-import %s: package cannot be enumerated", path)}
+		}
+	}
+	return aspec, nil
+}
+
+// Bind the package imported by spec in env. Dot imports declare the
+// exported identifiers of the package in the top scope of env.
+func interpImportSpec(spec *ImportSpec, env Env) {
+	switch spec.name {
+	case "_":
+	case ".":
+		pkg := spec.pkg.(*SimpleEnv)
+		pkg.mu.RLock()
+		defer pkg.mu.RUnlock()
+		for name, v := range pkg.Vars {
+			if ast.IsExported(name) {
+				env.AddVar(name, v)
+			}
+		}
+		for name, f := range pkg.Funcs {
+			if ast.IsExported(name) {
+				env.AddFunc(name, f)
+			}
+		}
+		for name, c := range pkg.Consts {
+			if ast.IsExported(name) {
+				env.AddConst(name, c)
+			}
+		}
+		for name, t := range pkg.Types {
+			if ast.IsExported(name) {
+				env.AddType(name, t)
+			}
+		}
+	default:
+		env.AddPkg(spec.name, spec.pkg)
+	}
+}
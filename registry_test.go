@@ -0,0 +1,114 @@
+package eval
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func makeRegistryEnv() *SimpleEnv {
+	pkg := MakeSimpleEnv()
+	pkg.Funcs["ToUpper"] = reflect.ValueOf(strings.ToUpper)
+	pkg.Funcs["lower"] = reflect.ValueOf(strings.ToLower)
+	pkg.Consts["Sep"] = reflect.ValueOf(",")
+
+	env := MakeSimpleEnv()
+	env.Registry = NewRegistry()
+	env.Registry.Register("example.com/text/v2", pkg)
+	return env
+}
+
+func TestImport(t *testing.T) {
+	env := makeRegistryEnv()
+	expectInterp(t, `import "example.com/text/v2"`, env)
+	expectResult(t, `text.ToUpper("a")`, env, "A")
+	if path := env.Pkg("text").(*SimpleEnv).Path; path != "example.com/text/v2" {
+		t.Fatalf("Path of registered package is %q", path)
+	}
+}
+
+func TestImportAlias(t *testing.T) {
+	env := makeRegistryEnv()
+	expectInterp(t, `import (t "example.com/text/v2"; _ "example.com/text/v2")`, env)
+	expectResult(t, `t.ToUpper(t.Sep)`, env, ",")
+	if env.Pkg("text") != nil || env.Pkg("_") != nil {
+		t.Fatalf("Import bound unexpected package names")
+	}
+}
+
+func TestImportDot(t *testing.T) {
+	env := makeRegistryEnv()
+	scope := env.PushScope()
+	expectInterp(t, `import . "example.com/text/v2"`, scope)
+	expectResult(t, `ToUpper("b") + Sep`, scope, "B,")
+	expectCheckError(t, `lower("B")`, scope, "undefined: lower")
+}
+
+func TestImportDefaultRegistry(t *testing.T) {
+	pkg := MakeSimpleEnv()
+	pkg.Consts["Answer"] = reflect.ValueOf(42)
+	RegisterPackage("example.com/registry/answer", pkg)
+
+	env := MakeSimpleEnv()
+	expectInterp(t, `import "example.com/registry/answer"`, env)
+	expectResult(t, `answer.Answer`, env, 42)
+}
+
+func TestImportPkgPath(t *testing.T) {
+	pkg := MakeSimpleEnv()
+	pkg.Path = "example.com/deep/name"
+	pkg.Consts["X"] = reflect.ValueOf("x")
+	env := MakeSimpleEnv()
+	env.Pkgs["deep"] = pkg
+
+	expectInterp(t, `import n "example.com/deep/name"`, env)
+	expectResult(t, `n.X`, env, "x")
+}
+
+func TestImportErrors(t *testing.T) {
+	env := makeRegistryEnv()
+	if _, _, errs := Interpret(`import "example.com/missing"`, env); len(errs) != 1 ||
+		errs[0].Error() != "could not import example.com/missing (no package registered with this import path)" {
+		t.Fatalf("Wrong errors %v", errs)
+	}
+	if _, _, errs := Interpret(`import ""`, env); len(errs) != 1 || errs[0].Error() != `invalid import path: ""` {
+		t.Fatalf("Wrong errors %v", errs)
+	}
+}
+
+func TestRunProgramImports(t *testing.T) {
+	var out []string
+	env := makeProgramEnv(&out)
+	env.Registry = makeRegistryEnv().Registry
+	src := `
+package main
+
+import (
+	"example.com/log"
+	. "example.com/text/v2"
+	str "example.com/text/v2"
+)
+
+func main() {
+	log.Print(ToUpper("a"), str.Sep)
+}
+`
+	if panik, errs := RunSource(src, env); errs != nil || panik != nil {
+		t.Fatalf("Failed to run program %v %v", errs, panik)
+	} else if !reflect.DeepEqual(out, []string{"A,"}) {
+		t.Fatalf("Wrong output %q", out)
+	}
+}
+
+func TestImportName(t *testing.T) {
+	for path, name := range map[string]string{
+		"strings": "strings",
+		"encoding/json": "json",
+		"gopkg.in/yaml.v2": "yaml",
+		"example.com/foo/v2": "foo",
+	} {
+		if actual := importName(path); actual != name {
+			t.Errorf("importName(%q) = %q, expected %q", path, actual, name)
+		}
+	}
+}
@@ -0,0 +1,486 @@
+package eval
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// A Session interprets statements in a SimpleEnv, recording the
+// declarations they make so that the session may be saved and reloaded
+// into a fresh SimpleEnv.
+//
+// Type, const, func, method and import declarations are saved as source
+// and reinterpreted when loaded. Variables are saved with their type and
+// current value, encoded by encoding/json. The value of an interface
+// variable is saved with its dynamic type. A variable holding a function
+// literal is instead saved as the source of the literal most recently
+// assigned to it by a top-level statement. Bindings provided
+// by the host, such as packages and host funcs, are only ever referred to
+// by name, so the env a session is loaded into must provide them too.
+type Session struct {
+	Env *SimpleEnv
+
+	decls []sessionDecl
+
+	// The source of the function literal held by each variable, if known
+	funcs map[string]string
+}
+
+// A declaration made by a session, either the source of a declaration or
+// the name of a variable.
+type sessionDecl struct {
+	source string
+	name string
+}
+
+// The encoding of a saved session, whose declarations are loaded in order.
+// Variables holding function literals are assigned once all other
+// declarations are loaded, so that the literals may refer to variables
+// declared after them.
+type savedSession struct {
+	Decls []savedDecl
+}
+
+type savedDecl struct {
+	Source string `json:",omitempty"`
+	Var string `json:",omitempty"`
+	Type string `json:",omitempty"`
+	// The dynamic type of the Value of an interface variable
+	Dynamic string `json:",omitempty"`
+	Value json.RawMessage `json:",omitempty"`
+	Func string `json:",omitempty"`
+}
+
+func NewSession(env *SimpleEnv) *Session {
+	return &Session{Env: env, funcs: map[string]string{}}
+}
+
+// Interpret stmt in the session's Env, as the package level Interpret
+// does, recording the declarations it makes. Statements which do not
+// compile or which panic are not recorded.
+func (s *Session) Interpret(stmt string) (result []reflect.Value, panik error, compileErrors []error) {
+	node, result, panik, compileErrors := interpret(stmt, s.Env)
+	if node != nil && panik == nil && compileErrors == nil {
+		s.record(node)
+	}
+	return
+}
+
+// Record the declarations and function literal assignments of stmt.
+func (s *Session) record(stmt ast.Stmt) {
+	switch stmt := stmt.(type) {
+	case *ast.DeclStmt:
+		decl, ok := stmt.Decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.VAR {
+			s.decls = append(s.decls, sessionDecl{source: nodeSource(stmt.Decl)})
+			return
+		}
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.ValueSpec)
+			for i, name := range spec.Names {
+				s.declareVar(name.Name)
+				if len(spec.Values) == len(spec.Names) {
+					s.assignFunc(name.Name, spec.Values[i])
+				}
+			}
+		}
+	case *ast.AssignStmt:
+		if stmt.Tok != token.ASSIGN && stmt.Tok != token.DEFINE {
+			return
+		}
+		for i, lhs := range stmt.Lhs {
+			ident, ok := lhs.(*ast.Ident)
+			if !ok {
+				continue
+			} else if stmt.Tok == token.DEFINE {
+				s.declareVar(ident.Name)
+			}
+			if len(stmt.Lhs) == len(stmt.Rhs) {
+				s.assignFunc(ident.Name, stmt.Rhs[i])
+			} else {
+				delete(s.funcs, ident.Name)
+			}
+		}
+	}
+}
+
+// Record the declaration of variable name, replacing any previous one.
+func (s *Session) declareVar(name string) {
+	if name == "_" {
+		return
+	}
+	for i, decl := range s.decls {
+		if decl.source == "" && decl.name == name {
+			s.decls = append(s.decls[:i], s.decls[i+1:]...)
+			break
+		}
+	}
+	s.decls = append(s.decls, sessionDecl{name: name})
+	delete(s.funcs, name)
+}
+
+// Record the assignment of rhs to variable name.
+func (s *Session) assignFunc(name string, rhs ast.Expr) {
+	switch rhs := skipParens(rhs).(type) {
+	case *ast.FuncLit:
+		s.funcs[name] = nodeSource(rhs)
+	case *ast.Ident:
+		if src, ok := s.funcs[rhs.Name]; ok {
+			s.funcs[name] = src
+			return
+		}
+		delete(s.funcs, name)
+	default:
+		delete(s.funcs, name)
+	}
+}
+
+// ErrUnsavedVars is returned by Session.Save when some variables could not
+// be saved. The rest of the session is saved regardless. Variables whose
+// type cannot be referred to are omitted, and variables whose value cannot
+// be encoded without loss, such as channels and structs with unexported
+// fields, are saved without their value.
+type ErrUnsavedVars struct {
+	Errs []error
+}
+
+func (err ErrUnsavedVars) Error() string {
+	msgs := make([]string, len(err.Errs))
+	for i, e := range err.Errs {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Save the declarations of the session to w. If some variables could not be
+// saved, the error is an ErrUnsavedVars.
+func (s *Session) Save(w io.Writer) error {
+	var saved savedSession
+	var unsaved []error
+	for _, decl := range s.decls {
+		if decl.source != "" {
+			saved.Decls = append(saved.Decls, savedDecl{Source: decl.source})
+			continue
+		}
+		v := s.Env.Var(decl.name)
+		if !v.IsValid() {
+			continue
+		}
+		t := varType(v)
+		texpr, err := typeExpr(t, s.Env)
+		if err != nil {
+			unsaved = append(unsaved, fmt.Errorf("cannot save %s: %v", decl.name, err))
+			continue
+		}
+		sdecl := savedDecl{Var: decl.name, Type: texpr}
+		if src, ok := s.funcs[decl.name]; ok {
+			sdecl.Func = src
+		} else if value := varValue(v); !value.IsZero() {
+			if err := s.saveValue(&sdecl, value, t); err != nil {
+				unsaved = append(unsaved, fmt.Errorf("cannot save value of %s: %v", decl.name, err))
+				sdecl.Dynamic, sdecl.Value = "", nil
+			}
+		}
+		saved.Decls = append(saved.Decls, sdecl)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	if err := enc.Encode(saved); err != nil {
+		return err
+	} else if unsaved != nil {
+		return ErrUnsavedVars{unsaved}
+	}
+	return nil
+}
+
+// Encode value, of static type t, as the Value of decl. The value of an
+// interface variable is encoded along with its dynamic type.
+func (s *Session) saveValue(decl *savedDecl, value reflect.Value, t reflect.Type) (err error) {
+	if t.Kind() == reflect.Interface {
+		var dynamicT reflect.Type
+		value, dynamicT = dynamicValue(value)
+		if decl.Dynamic, err = typeExpr(dynamicT, s.Env); err != nil {
+			return err
+		}
+	}
+	if err := checkSavable(value.Type(), map[reflect.Type]bool{}); err != nil {
+		return err
+	}
+	decl.Value, err = json.Marshal(value.Interface())
+	return err
+}
+
+var jsonMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// Returns an error if values of type t would not be the same once encoded
+// and decoded by encoding/json. Types which encode themselves are trusted.
+func checkSavable(t reflect.Type, seen map[reflect.Type]bool) error {
+	if seen[t] || t.Implements(jsonMarshaler) || reflect.PtrTo(t).Implements(jsonMarshaler) {
+		return nil
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return fmt.Errorf("unsupported type %v", t)
+	case reflect.Interface:
+		// Only the dynamic types of variables are saved
+		return fmt.Errorf("unsupported nested interface type %v", t)
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return checkSavable(t.Elem(), seen)
+	case reflect.Map:
+		if err := checkSavable(t.Key(), seen); err != nil {
+			return err
+		}
+		return checkSavable(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i += 1 {
+			f := t.Field(i)
+			if f.PkgPath != "" && !f.Anonymous {
+				return fmt.Errorf("unexported field %s", f.Name)
+			} else if err := checkSavable(f.Type, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// LoadSession loads a session saved by Session.Save into env, returning a
+// Session recording the loaded declarations.
+func LoadSession(r io.Reader, env *SimpleEnv) (*Session, error) {
+	var saved savedSession
+	if err := json.NewDecoder(r).Decode(&saved); err != nil {
+		return nil, err
+	}
+	s := NewSession(env)
+	var funcs []savedDecl
+	for _, decl := range saved.Decls {
+		if decl.Source != "" {
+			if err := s.load(decl.Source); err != nil {
+				return nil, err
+			}
+			continue
+		} else if err := s.load(fmt.Sprintf("var %s %s", decl.Var, decl.Type)); err != nil {
+			return nil, err
+		}
+		if decl.Func != "" {
+			funcs = append(funcs, decl)
+		} else if decl.Value != nil {
+			if err := s.loadValue(decl); err != nil {
+				return nil, fmt.Errorf("cannot load %s: %v", decl.Var, err)
+			}
+		}
+	}
+	for _, decl := range funcs {
+		if err := s.load(decl.Var + " = " + decl.Func); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Decode the Value of decl into its declared variable.
+func (s *Session) loadValue(decl savedDecl) error {
+	v := s.Env.Var(decl.Var)
+	value := varValue(v)
+	if decl.Dynamic == "" {
+		return json.Unmarshal(decl.Value, value.Addr().Interface())
+	}
+	expr, err := parser.ParseExpr(decl.Dynamic)
+	if err != nil {
+		return err
+	}
+	_, dynamicT, isType, errs := checkType(expr, s.Env)
+	if errs != nil {
+		return errs[0]
+	} else if !isType {
+		return fmt.Errorf("%s is not a type", decl.Dynamic)
+	}
+	dynamic := hackedNew(dynamicT).Elem()
+	if err := json.Unmarshal(decl.Value, dynamic.Addr().Interface()); err != nil {
+		return err
+	}
+	value.Set(interfaceValue(dynamic, dynamicT, varType(v)))
+	return nil
+}
+
+func (s *Session) load(stmt string) error {
+	if _, panik, errs := s.Interpret(stmt); errs != nil {
+		return fmt.Errorf("cannot load %s: %v", stmt, errs[0])
+	} else if panik != nil {
+		return fmt.Errorf("cannot load %s: %v", stmt, panik)
+	}
+	return nil
+}
+
+func nodeSource(node ast.Node) string {
+	var buf bytes.Buffer
+	format.Node(&buf, token.NewFileSet(), node)
+	return buf.String()
+}
+
+// Returns a type expression denoting t in env. Types declared in env or in
+// its packages are referred to by name.
+func typeExpr(t reflect.Type, env *SimpleEnv) (string, error) {
+	if name, ok := typeName(t, env); ok {
+		return name, nil
+	}
+	if iface, ok := t.(*Interface); ok {
+		return interfaceExpr(iface.methods, env)
+	} else if _, ok := t.(*Named); ok || t.Name() != "" {
+		return "", fmt.Errorf("type %v is not declared in env", t)
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem, err := typeExpr(t.Elem(), env)
+		return "*" + elem, err
+	case reflect.Slice:
+		elem, err := typeExpr(t.Elem(), env)
+		return "[]" + elem, err
+	case reflect.Array:
+		elem, err := typeExpr(t.Elem(), env)
+		return fmt.Sprintf("[%d]%s", t.Len(), elem), err
+	case reflect.Map:
+		key, err := typeExpr(t.Key(), env)
+		if err != nil {
+			return "", err
+		}
+		elem, err := typeExpr(t.Elem(), env)
+		return "map[" + key + "]" + elem, err
+	case reflect.Chan:
+		elem, err := typeExpr(t.Elem(), env)
+		switch t.ChanDir() {
+		case reflect.RecvDir:
+			return "<-chan " + elem, err
+		case reflect.SendDir:
+			return "chan<- " + elem, err
+		}
+		if t.Elem().Kind() == reflect.Chan && t.Elem().ChanDir() == reflect.RecvDir {
+			elem = "(" + elem + ")"
+		}
+		return "chan " + elem, err
+	case reflect.Func:
+		var in, out []string
+		for i := 0; i < t.NumIn(); i += 1 {
+			param, err := typeExpr(t.In(i), env)
+			if err != nil {
+				return "", err
+			}
+			if t.IsVariadic() && i == t.NumIn() - 1 {
+				param = "..." + strings.TrimPrefix(param, "[]")
+			}
+			in = append(in, param)
+		}
+		for i := 0; i < t.NumOut(); i += 1 {
+			result, err := typeExpr(t.Out(i), env)
+			if err != nil {
+				return "", err
+			}
+			out = append(out, result)
+		}
+		sig := "func(" + strings.Join(in, ", ") + ")"
+		if len(out) == 1 {
+			sig += " " + out[0]
+		} else if len(out) > 1 {
+			sig += " (" + strings.Join(out, ", ") + ")"
+		}
+		return sig, nil
+	case reflect.Struct:
+		var fields []string
+		for i := 0; i < t.NumField(); i += 1 {
+			f := t.Field(i)
+			field, err := typeExpr(f.Type, env)
+			if err != nil {
+				return "", err
+			}
+			if !f.Anonymous {
+				field = f.Name + " " + field
+			}
+			if f.Tag != "" {
+				field += " " + fmt.Sprintf("%q", f.Tag)
+			}
+			fields = append(fields, field)
+		}
+		return "struct { " + strings.Join(fields, "; ") + " }", nil
+	case reflect.Interface:
+		var methods []reflect.Method
+		for i := 0; i < t.NumMethod(); i += 1 {
+			methods = append(methods, t.Method(i))
+		}
+		return interfaceExpr(methods, env)
+	}
+	return "", fmt.Errorf("cannot refer to type %v", t)
+}
+
+// Returns an interface type expression with the given methods, whose Types
+// exclude the receiver.
+func interfaceExpr(methods []reflect.Method, env *SimpleEnv) (string, error) {
+	if len(methods) == 0 {
+		return "interface{}", nil
+	}
+	var elems []string
+	for _, m := range methods {
+		sig, err := typeExpr(m.Type, env)
+		if err != nil {
+			return "", err
+		}
+		elems = append(elems, m.Name + strings.TrimPrefix(sig, "func"))
+	}
+	return "interface { " + strings.Join(elems, "; ") + " }", nil
+}
+
+// Returns the name of t if it is predeclared, or declared in env or one
+// of its packages.
+func typeName(t reflect.Type, env *SimpleEnv) (string, bool) {
+	if _, ok := t.(*Named); !ok && t.Name() != "" && t.PkgPath() == "" {
+		return t.Name(), true
+	}
+	for scope := env; scope != nil; scope = scope.Parent {
+		if name, ok := lookupTypeName(t, scope, false); ok {
+			return name, true
+		}
+	}
+	root := rootSimpleEnv(env)
+	root.mu.RLock()
+	defer root.mu.RUnlock()
+	var names []string
+	for name := range root.Pkgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if pkg, ok := root.Pkgs[name].(*SimpleEnv); ok {
+			if tname, ok := lookupTypeName(t, pkg, true); ok {
+				return name + "." + tname, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Returns the first name, in sorted order, of t in the Types of env.
+func lookupTypeName(t reflect.Type, env *SimpleEnv, exported bool) (string, bool) {
+	env.mu.RLock()
+	defer env.mu.RUnlock()
+	var names []string
+	for name, u := range env.Types {
+		if u == t && (!exported || ast.IsExported(name)) {
+			names = append(names, name)
+		}
+	}
+	if names == nil {
+		return "", false
+	}
+	sort.Strings(names)
+	return names[0], true
+}
@@ -0,0 +1,166 @@
+package eval
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Returns an env providing the host package "example.com/geo" and the
+// host func double.
+func makeSessionEnv() *SimpleEnv {
+	type Point struct{ X, Y int }
+	geo := MakeSimpleEnv()
+	geo.Types["Point"] = reflect.TypeOf(Point{})
+	env := MakeSimpleEnv()
+	env.Pkgs["geo"] = geo
+	env.Funcs["double"] = reflect.ValueOf(func(i int) int { return i * 2 })
+	return env
+}
+
+func interpretSession(t *testing.T, s *Session, stmts ...string) {
+	for _, stmt := range stmts {
+		if _, panik, errs := s.Interpret(stmt); errs != nil || panik != nil {
+			t.Fatalf("Failed to interpret %s: %v %v", stmt, errs, panik)
+		}
+	}
+}
+
+func reloadSession(t *testing.T, s *Session) (*Session, string) {
+	var buf bytes.Buffer
+	if err := s.Save(&buf); err != nil {
+		t.Fatalf("Failed to save session %v", err)
+	}
+	saved := buf.String()
+	loaded, err := LoadSession(&buf, makeSessionEnv())
+	if err != nil {
+		t.Fatalf("Failed to load session %v\n%s", err, saved)
+	}
+	return loaded, saved
+}
+
+func TestSession(t *testing.T) {
+	s := NewSession(makeSessionEnv())
+	interpretSession(t, s,
+		`type Celsius float64`,
+		`func (c Celsius) Double() Celsius { return c * 2 }`,
+		`const Freezing Celsius = 0`,
+		`t := Freezing`,
+		`names := map[string][]int{"a": {1, 2}}`,
+		`p := geo.Point{X: 1}`,
+		`var unset []string`,
+		`n := 3`,
+		`scale := func(i int) int { return double(i) * n }`,
+		`t += 22.5`,
+	)
+	loaded, saved := reloadSession(t, s)
+	env := loaded.Env
+	expectResult(t, `float64(t.Double())`, env, 45.0)
+	expectResult(t, `names["a"][1] + p.X + len(unset)`, env, 3)
+	expectResult(t, `scale(2)`, env, 12)
+
+	for _, unexpected := range []string{"double(i) * n }\"", `"Var": "double"`, `"Var": "geo"`} {
+		if strings.Contains(saved, unexpected) {
+			t.Errorf("Saved session contains %s", unexpected)
+		}
+	}
+	if !strings.Contains(saved, `"Type": "geo.Point"`) {
+		t.Errorf("Host type is not referred to by name\n%s", saved)
+	}
+
+	// A reloaded session may itself be saved
+	loaded, _ = reloadSession(t, loaded)
+	expectResult(t, `scale(1) + n`, loaded.Env, 9)
+}
+
+func TestSessionRedeclare(t *testing.T) {
+	s := NewSession(makeSessionEnv())
+	interpretSession(t, s,
+		`x := 1`,
+		`f := func() int { return 2 }`,
+		`x := "one"`,
+		`g := f`,
+		`f = nil`,
+	)
+	loaded, _ := reloadSession(t, s)
+	expectResult(t, `x`, loaded.Env, "one")
+	expectResult(t, `f == nil`, loaded.Env, true)
+	expectResult(t, `g()`, loaded.Env, 2)
+}
+
+func TestSessionPanic(t *testing.T) {
+	s := NewSession(makeSessionEnv())
+	interpretSession(t, s,
+		`fail := func() func() int { panic("p") }`,
+		`f := func() int { return 1 }`,
+	)
+	for _, stmt := range []string{`x := fail()`, `f = fail()`} {
+		if _, panik, errs := s.Interpret(stmt); errs != nil || panik == nil {
+			t.Fatalf("Expected %s to panic, got %v %v", stmt, errs, panik)
+		}
+	}
+	loaded, _ := reloadSession(t, s)
+	expectCheckError(t, `x`, loaded.Env, "undefined: x")
+	expectResult(t, `f()`, loaded.Env, 1)
+}
+
+func TestSessionInterfaceVars(t *testing.T) {
+	s := NewSession(makeSessionEnv())
+	interpretSession(t, s,
+		`type Celsius float64`,
+		`var i interface{} = Celsius(2)`,
+		`var j interface{} = geo.Point{X: 1}`,
+		`var k interface{} = 3`,
+		`var l interface{ Double() Celsius }`,
+		`func (c Celsius) Double() Celsius { return c * 2 }`,
+		`l = Celsius(4)`,
+	)
+	loaded, _ := reloadSession(t, s)
+	expectResult(t, `float64(i.(Celsius))`, loaded.Env, 2.0)
+	expectResult(t, `j.(geo.Point).X`, loaded.Env, 1)
+	expectResult(t, `int(k.(int))`, loaded.Env, 3)
+	expectResult(t, `float64(l.Double())`, loaded.Env, 8.0)
+}
+
+func TestSessionSaveErrors(t *testing.T) {
+	s := NewSession(makeSessionEnv())
+	interpretSession(t, s,
+		`type P struct{ X int; s string }`,
+		`p := P{1, "s"}`,
+		`c := make(chan int)`,
+		`send := func() { c <- 1 }`,
+		`xs := []interface{}{1}`,
+		`n := 1`,
+	)
+	var buf bytes.Buffer
+	err := s.Save(&buf)
+	expected := []string{
+		"cannot save value of p: unexported field s",
+		"cannot save value of c: unsupported type chan int",
+		"cannot save value of xs: unsupported nested interface type interface {}",
+	}
+	if unsaved, ok := err.(ErrUnsavedVars); !ok {
+		t.Fatalf("Expected ErrUnsavedVars, got %v", err)
+	} else if len(unsaved.Errs) != len(expected) {
+		t.Fatalf("Wrong errors %v", unsaved.Errs)
+	} else {
+		for i, e := range unsaved.Errs {
+			if e.Error() != expected[i] {
+				t.Fatalf("Wrong error %v, expected %s", e, expected[i])
+			}
+		}
+	}
+
+	// Variables which could not be saved are declared, but zero
+	loaded, err := LoadSession(&buf, makeSessionEnv())
+	if err != nil {
+		t.Fatalf("Failed to load session %v", err)
+	}
+	expectResult(t, `p.X`, loaded.Env, 0)
+	expectResult(t, `send != nil && xs == nil`, loaded.Env, true)
+	if !loaded.Env.Var("c").Elem().IsNil() {
+		t.Fatalf("Expected c to be nil")
+	}
+	expectResult(t, `n`, loaded.Env, 1)
+}
@@ -0,0 +1,225 @@
+package eval
+
+import (
+	"errors"
+	"reflect"
+)
+
+// A Snapshot records the declarations of a SimpleEnv and each of its
+// parent scopes, along with the values of their variables, as returned by
+// SimpleEnv.Snapshot. A Snapshot may be restored any number of times.
+type Snapshot struct {
+	scopes []scopeSnapshot
+	depth int
+}
+
+type scopeSnapshot struct {
+	env *SimpleEnv
+	vars map[string]reflect.Value
+	funcs map[string]reflect.Value
+	consts map[string]reflect.Value
+	types map[string]reflect.Type
+	pkgs map[string]Env
+
+	// Copies of the values pointed to by vars
+	values map[string]reflect.Value
+}
+
+var errForeignSnapshot = errors.New("snapshot was not taken of this env")
+
+// Snapshot records the state of env and its parent scopes. The values of
+// variables are copied to depth levels of indirection through pointers,
+// slices, maps and interfaces, beyond which they are shared with the live
+// variables and so are not rolled back by Restore. A depth of zero copies
+// only the values of the variables themselves, as an assignment would, and
+// a negative depth copies every reachable value. Packages are not copied.
+func (env *SimpleEnv) Snapshot(depth int) *Snapshot {
+	s := &Snapshot{depth: depth}
+	seen := map[copyKey]reflect.Value{}
+	for scope := env; scope != nil; scope = scope.Parent {
+		scope.mu.RLock()
+		ss := scopeSnapshot{
+			env: scope,
+			vars: copyValueMap(scope.Vars),
+			funcs: copyValueMap(scope.Funcs),
+			consts: copyValueMap(scope.Consts),
+			types: map[string]reflect.Type{},
+			pkgs: map[string]Env{},
+			values: map[string]reflect.Value{},
+		}
+		for name, t := range scope.Types {
+			ss.types[name] = t
+		}
+		for name, p := range scope.Pkgs {
+			ss.pkgs[name] = p
+		}
+		scope.mu.RUnlock()
+		for name, v := range ss.vars {
+			ss.values[name] = copyValue(v.Elem(), depth, seen)
+		}
+		s.scopes = append(s.scopes, ss)
+	}
+	return s
+}
+
+// Restore env and its parent scopes to the state recorded by s, which must
+// have been taken of env. Declarations made since are removed, and
+// variables are reset in place, so that function literals which captured
+// them observe the restored values.
+func (env *SimpleEnv) Restore(s *Snapshot) error {
+	if len(s.scopes) == 0 || s.scopes[0].env != env {
+		return errForeignSnapshot
+	}
+	seen := map[copyKey]reflect.Value{}
+	for _, ss := range s.scopes {
+		scope := ss.env
+		scope.mu.Lock()
+		scope.Vars = copyValueMap(ss.vars)
+		scope.Funcs = copyValueMap(ss.funcs)
+		scope.Consts = copyValueMap(ss.consts)
+		scope.Types = map[string]reflect.Type{}
+		for name, t := range ss.types {
+			scope.Types[name] = t
+		}
+		scope.Pkgs = map[string]Env{}
+		for name, p := range ss.pkgs {
+			scope.Pkgs[name] = p
+		}
+		scope.mu.Unlock()
+		for name, v := range ss.vars {
+			v.Elem().Set(copyValue(ss.values[name], s.depth, seen))
+		}
+	}
+	return nil
+}
+
+// Fork returns an independent copy of env and its parent scopes. Each
+// variable of the fork is a new variable holding a copy of the original
+// value, copied to depth levels of indirection as described by Snapshot.
+// Variables referring to each other within the copied depth continue to do
+// so in the fork. Funcs, including evaluated function literals, are shared,
+// so function literals continue to refer to the variables they captured
+// from env.
+func (env *SimpleEnv) Fork(depth int) *SimpleEnv {
+	if depth >= 0 {
+		// One more level for the variable pointers themselves
+		depth += 1
+	}
+	seen := map[copyKey]reflect.Value{}
+	var fork, child *SimpleEnv
+	for scope := env; scope != nil; scope = scope.Parent {
+		copied := MakeSimpleEnv()
+		scope.mu.RLock()
+		copied.Path = scope.Path
+		copied.Registry = scope.Registry
+		for name, v := range scope.Vars {
+			copied.Vars[name] = copyValue(v, depth, seen)
+		}
+		for name, f := range scope.Funcs {
+			copied.Funcs[name] = f
+		}
+		for name, c := range scope.Consts {
+			copied.Consts[name] = c
+		}
+		for name, t := range scope.Types {
+			copied.Types[name] = t
+		}
+		for name, p := range scope.Pkgs {
+			copied.Pkgs[name] = p
+		}
+		scope.mu.RUnlock()
+		if child == nil {
+			fork = copied
+		} else {
+			child.Parent = copied
+		}
+		child = copied
+	}
+	return fork
+}
+
+func copyValueMap(m map[string]reflect.Value) map[string]reflect.Value {
+	c := make(map[string]reflect.Value, len(m))
+	for name, v := range m {
+		c[name] = v
+	}
+	return c
+}
+
+// Identifies a pointer, slice or map already copied, so that values
+// sharing memory continue to share it in the copy.
+type copyKey struct {
+	t reflect.Type
+	p uintptr
+	n int
+}
+
+// Returns a copy of v. Pointers, slices, maps and interfaces are followed
+// to depth levels of indirection, beyond which they are shared with v. A
+// negative depth copies all reachable values. Chans, funcs and unexported
+// struct fields are always shared.
+func copyValue(v reflect.Value, depth int, seen map[copyKey]reflect.Value) reflect.Value {
+	t := v.Type()
+	c := reflect.New(t).Elem()
+	c.Set(v)
+	if depth == 0 {
+		return c
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			break
+		}
+		key := copyKey{t, v.Pointer(), 0}
+		if p, ok := seen[key]; ok {
+			return p
+		}
+		p := reflect.New(t.Elem())
+		seen[key] = p
+		p.Elem().Set(copyValue(v.Elem(), depth - 1, seen))
+		c.Set(p)
+	case reflect.Slice:
+		if v.IsNil() {
+			break
+		}
+		key := copyKey{t, v.Pointer(), v.Len()}
+		if s, ok := seen[key]; ok {
+			return s
+		}
+		s := reflect.MakeSlice(t, v.Len(), v.Cap())
+		seen[key] = s
+		for i := 0; i < v.Len(); i += 1 {
+			s.Index(i).Set(copyValue(v.Index(i), depth - 1, seen))
+		}
+		c.Set(s)
+	case reflect.Map:
+		if v.IsNil() {
+			break
+		}
+		key := copyKey{t, v.Pointer(), 0}
+		if m, ok := seen[key]; ok {
+			return m
+		}
+		m := reflect.MakeMapWithSize(t, v.Len())
+		seen[key] = m
+		for iter := v.MapRange(); iter.Next(); {
+			m.SetMapIndex(copyValue(iter.Key(), depth - 1, seen), copyValue(iter.Value(), depth - 1, seen))
+		}
+		c.Set(m)
+	case reflect.Interface:
+		if !v.IsNil() {
+			c.Set(copyValue(v.Elem(), depth - 1, seen))
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i += 1 {
+			c.Index(i).Set(copyValue(v.Index(i), depth, seen))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i += 1 {
+			if field := c.Field(i); field.CanSet() {
+				field.Set(copyValue(v.Field(i), depth, seen))
+			}
+		}
+	}
+	return c
+}
@@ -0,0 +1,93 @@
+package eval
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, `x := 1`, env)
+	expectInterp(t, `xs := []int{1, 2}`, env)
+	expectInterp(t, `get := func() int { return x }`, env)
+
+	s := env.Snapshot(1)
+	expectInterp(t, `x = 2`, env)
+	expectInterp(t, `xs[0] = 3`, env)
+	expectInterp(t, `y := "new"`, env)
+	expectInterp(t, `type T int`, env)
+	expectResult(t, `get() + xs[0]`, env, 5)
+
+	if err := env.Restore(s); err != nil {
+		t.Fatal(err)
+	}
+	expectResult(t, `get() + xs[0]`, env, 2)
+	expectCheckError(t, `y`, env, "undefined: y")
+	expectCheckError(t, `T(1)`, env, "undefined: T")
+
+	// Snapshots may be restored repeatedly
+	expectInterp(t, `x = 10`, env)
+	if err := env.Restore(s); err != nil {
+		t.Fatal(err)
+	}
+	expectResult(t, `x`, env, 1)
+}
+
+func TestSnapshotDepth(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, `xs := []int{1, 2}`, env)
+	s := env.Snapshot(0)
+	expectInterp(t, `xs[0] = 3`, env)
+	expectInterp(t, `xs = append(xs, 4)`, env)
+	env.Restore(s)
+	// The slice itself was restored, but not its shared elements
+	expectResult(t, `len(xs)`, env, 2)
+	expectResult(t, `xs[0]`, env, 3)
+
+	expectInterp(t, `m := map[string][]int{"a": {1}}`, env)
+	s = env.Snapshot(-1)
+	expectInterp(t, `m["a"][0] = 2`, env)
+	env.Restore(s)
+	expectResult(t, `m["a"][0]`, env, 1)
+}
+
+func TestSnapshotScopes(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, `x := 1`, env)
+	scope := env.PushScope().(*SimpleEnv)
+	expectInterp(t, `y := 2`, scope)
+	s := scope.Snapshot(0)
+	expectInterp(t, `x, y = 3, 4`, scope)
+	expectInterp(t, `z := 5`, env)
+
+	if err := env.Restore(s); err == nil {
+		t.Fatalf("Expected restoring a snapshot of another env to fail")
+	} else if err := scope.Restore(s); err != nil {
+		t.Fatal(err)
+	}
+	expectResult(t, `x + y`, scope, 3)
+	expectCheckError(t, `z`, env, "undefined: z")
+}
+
+func TestFork(t *testing.T) {
+	env := MakeSimpleEnv()
+	expectInterp(t, `x := 1`, env)
+	expectInterp(t, `p := &x`, env)
+	expectInterp(t, `xs := []int{1}`, env)
+
+	fork := env.Fork(1)
+	expectInterp(t, `*p = 2`, fork)
+	expectInterp(t, `xs[0] = 2`, fork)
+	expectInterp(t, `y := 3`, fork)
+	expectResult(t, `x + xs[0] + y`, fork, 7)
+	expectResult(t, `x + xs[0]`, env, 2)
+	expectCheckError(t, `y`, env, "undefined: y")
+
+	// Beyond the copied depth, values are shared
+	shallow := env.Fork(0)
+	expectInterp(t, `xs[0] = 5`, shallow)
+	expectResult(t, `xs[0]`, env, 5)
+	if !reflect.DeepEqual(env.Var("x").Elem().Interface(), 1) {
+		t.Fatalf("Fork modified x")
+	}
+}
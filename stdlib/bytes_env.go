@@ -0,0 +1,83 @@
+// Code generated by genenv; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+
+	bytespkg "bytes"
+	"github.com/0xfaded/eval"
+)
+
+func init() {
+	eval.RegisterPackage("bytes", BytesEnv())
+}
+
+// Funcs adding the declarations of package bytes introduced after go1.18,
+// appended by files with build constraints.
+var bytesEnvVersions []func(*eval.SimpleEnv)
+
+// BytesEnv returns an Env holding the exported declarations of package bytes.
+func BytesEnv() *eval.SimpleEnv {
+	env := eval.MakeSimpleEnv()
+	env.Path = "bytes"
+	env.Types["Buffer"] = reflect.TypeOf((*bytespkg.Buffer)(nil)).Elem()
+	env.Funcs["Compare"] = reflect.ValueOf(bytespkg.Compare)
+	env.Funcs["Contains"] = reflect.ValueOf(bytespkg.Contains)
+	env.Funcs["ContainsAny"] = reflect.ValueOf(bytespkg.ContainsAny)
+	env.Funcs["ContainsRune"] = reflect.ValueOf(bytespkg.ContainsRune)
+	env.Funcs["Count"] = reflect.ValueOf(bytespkg.Count)
+	env.Funcs["Cut"] = reflect.ValueOf(bytespkg.Cut)
+	env.Funcs["Equal"] = reflect.ValueOf(bytespkg.Equal)
+	env.Funcs["EqualFold"] = reflect.ValueOf(bytespkg.EqualFold)
+	env.Vars["ErrTooLarge"] = reflect.ValueOf(&bytespkg.ErrTooLarge)
+	env.Funcs["Fields"] = reflect.ValueOf(bytespkg.Fields)
+	env.Funcs["FieldsFunc"] = reflect.ValueOf(bytespkg.FieldsFunc)
+	env.Funcs["HasPrefix"] = reflect.ValueOf(bytespkg.HasPrefix)
+	env.Funcs["HasSuffix"] = reflect.ValueOf(bytespkg.HasSuffix)
+	env.Funcs["Index"] = reflect.ValueOf(bytespkg.Index)
+	env.Funcs["IndexAny"] = reflect.ValueOf(bytespkg.IndexAny)
+	env.Funcs["IndexByte"] = reflect.ValueOf(bytespkg.IndexByte)
+	env.Funcs["IndexFunc"] = reflect.ValueOf(bytespkg.IndexFunc)
+	env.Funcs["IndexRune"] = reflect.ValueOf(bytespkg.IndexRune)
+	env.Funcs["Join"] = reflect.ValueOf(bytespkg.Join)
+	env.Funcs["LastIndex"] = reflect.ValueOf(bytespkg.LastIndex)
+	env.Funcs["LastIndexAny"] = reflect.ValueOf(bytespkg.LastIndexAny)
+	env.Funcs["LastIndexByte"] = reflect.ValueOf(bytespkg.LastIndexByte)
+	env.Funcs["LastIndexFunc"] = reflect.ValueOf(bytespkg.LastIndexFunc)
+	env.Funcs["Map"] = reflect.ValueOf(bytespkg.Map)
+	env.Consts["MinRead"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "512", "0"))
+	env.Funcs["NewBuffer"] = reflect.ValueOf(bytespkg.NewBuffer)
+	env.Funcs["NewBufferString"] = reflect.ValueOf(bytespkg.NewBufferString)
+	env.Funcs["NewReader"] = reflect.ValueOf(bytespkg.NewReader)
+	env.Types["Reader"] = reflect.TypeOf((*bytespkg.Reader)(nil)).Elem()
+	env.Funcs["Repeat"] = reflect.ValueOf(bytespkg.Repeat)
+	env.Funcs["Replace"] = reflect.ValueOf(bytespkg.Replace)
+	env.Funcs["ReplaceAll"] = reflect.ValueOf(bytespkg.ReplaceAll)
+	env.Funcs["Runes"] = reflect.ValueOf(bytespkg.Runes)
+	env.Funcs["Split"] = reflect.ValueOf(bytespkg.Split)
+	env.Funcs["SplitAfter"] = reflect.ValueOf(bytespkg.SplitAfter)
+	env.Funcs["SplitAfterN"] = reflect.ValueOf(bytespkg.SplitAfterN)
+	env.Funcs["SplitN"] = reflect.ValueOf(bytespkg.SplitN)
+	env.Funcs["Title"] = reflect.ValueOf(bytespkg.Title)
+	env.Funcs["ToLower"] = reflect.ValueOf(bytespkg.ToLower)
+	env.Funcs["ToLowerSpecial"] = reflect.ValueOf(bytespkg.ToLowerSpecial)
+	env.Funcs["ToTitle"] = reflect.ValueOf(bytespkg.ToTitle)
+	env.Funcs["ToTitleSpecial"] = reflect.ValueOf(bytespkg.ToTitleSpecial)
+	env.Funcs["ToUpper"] = reflect.ValueOf(bytespkg.ToUpper)
+	env.Funcs["ToUpperSpecial"] = reflect.ValueOf(bytespkg.ToUpperSpecial)
+	env.Funcs["ToValidUTF8"] = reflect.ValueOf(bytespkg.ToValidUTF8)
+	env.Funcs["Trim"] = reflect.ValueOf(bytespkg.Trim)
+	env.Funcs["TrimFunc"] = reflect.ValueOf(bytespkg.TrimFunc)
+	env.Funcs["TrimLeft"] = reflect.ValueOf(bytespkg.TrimLeft)
+	env.Funcs["TrimLeftFunc"] = reflect.ValueOf(bytespkg.TrimLeftFunc)
+	env.Funcs["TrimPrefix"] = reflect.ValueOf(bytespkg.TrimPrefix)
+	env.Funcs["TrimRight"] = reflect.ValueOf(bytespkg.TrimRight)
+	env.Funcs["TrimRightFunc"] = reflect.ValueOf(bytespkg.TrimRightFunc)
+	env.Funcs["TrimSpace"] = reflect.ValueOf(bytespkg.TrimSpace)
+	env.Funcs["TrimSuffix"] = reflect.ValueOf(bytespkg.TrimSuffix)
+	for _, add := range bytesEnvVersions {
+		add(env)
+	}
+	return env
+}
@@ -0,0 +1,22 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.20
+
+package stdlib
+
+import (
+	"reflect"
+
+	bytespkg "bytes"
+	"github.com/0xfaded/eval"
+)
+
+// Declarations of package bytes added in go1.20.
+var _ = func() bool {
+	bytesEnvVersions = append(bytesEnvVersions, func(env *eval.SimpleEnv) {
+		env.Funcs["Clone"] = reflect.ValueOf(bytespkg.Clone)
+		env.Funcs["CutPrefix"] = reflect.ValueOf(bytespkg.CutPrefix)
+		env.Funcs["CutSuffix"] = reflect.ValueOf(bytespkg.CutSuffix)
+	})
+	return true
+}()
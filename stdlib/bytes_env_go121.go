@@ -0,0 +1,20 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.21
+
+package stdlib
+
+import (
+	"reflect"
+
+	bytespkg "bytes"
+	"github.com/0xfaded/eval"
+)
+
+// Declarations of package bytes added in go1.21.
+var _ = func() bool {
+	bytesEnvVersions = append(bytesEnvVersions, func(env *eval.SimpleEnv) {
+		env.Funcs["ContainsFunc"] = reflect.ValueOf(bytespkg.ContainsFunc)
+	})
+	return true
+}()
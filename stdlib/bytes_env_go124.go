@@ -0,0 +1,24 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.24
+
+package stdlib
+
+import (
+	"reflect"
+
+	bytespkg "bytes"
+	"github.com/0xfaded/eval"
+)
+
+// Declarations of package bytes added in go1.24.
+var _ = func() bool {
+	bytesEnvVersions = append(bytesEnvVersions, func(env *eval.SimpleEnv) {
+		env.Funcs["FieldsFuncSeq"] = reflect.ValueOf(bytespkg.FieldsFuncSeq)
+		env.Funcs["FieldsSeq"] = reflect.ValueOf(bytespkg.FieldsSeq)
+		env.Funcs["Lines"] = reflect.ValueOf(bytespkg.Lines)
+		env.Funcs["SplitAfterSeq"] = reflect.ValueOf(bytespkg.SplitAfterSeq)
+		env.Funcs["SplitSeq"] = reflect.ValueOf(bytespkg.SplitSeq)
+	})
+	return true
+}()
@@ -0,0 +1,32 @@
+// Code generated by genenv; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+
+	errorspkg "errors"
+	"github.com/0xfaded/eval"
+)
+
+func init() {
+	eval.RegisterPackage("errors", ErrorsEnv())
+}
+
+// Funcs adding the declarations of package errors introduced after go1.18,
+// appended by files with build constraints.
+var errorsEnvVersions []func(*eval.SimpleEnv)
+
+// ErrorsEnv returns an Env holding the exported declarations of package errors.
+func ErrorsEnv() *eval.SimpleEnv {
+	env := eval.MakeSimpleEnv()
+	env.Path = "errors"
+	env.Funcs["As"] = reflect.ValueOf(errorspkg.As)
+	env.Funcs["Is"] = reflect.ValueOf(errorspkg.Is)
+	env.Funcs["New"] = reflect.ValueOf(errorspkg.New)
+	env.Funcs["Unwrap"] = reflect.ValueOf(errorspkg.Unwrap)
+	for _, add := range errorsEnvVersions {
+		add(env)
+	}
+	return env
+}
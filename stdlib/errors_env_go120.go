@@ -0,0 +1,20 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.20
+
+package stdlib
+
+import (
+	"reflect"
+
+	errorspkg "errors"
+	"github.com/0xfaded/eval"
+)
+
+// Declarations of package errors added in go1.20.
+var _ = func() bool {
+	errorsEnvVersions = append(errorsEnvVersions, func(env *eval.SimpleEnv) {
+		env.Funcs["Join"] = reflect.ValueOf(errorspkg.Join)
+	})
+	return true
+}()
@@ -0,0 +1,53 @@
+// Code generated by genenv; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+
+	fmtpkg "fmt"
+	"github.com/0xfaded/eval"
+)
+
+func init() {
+	eval.RegisterPackage("fmt", FmtEnv())
+}
+
+// Funcs adding the declarations of package fmt introduced after go1.18,
+// appended by files with build constraints.
+var fmtEnvVersions []func(*eval.SimpleEnv)
+
+// FmtEnv returns an Env holding the exported declarations of package fmt.
+func FmtEnv() *eval.SimpleEnv {
+	env := eval.MakeSimpleEnv()
+	env.Path = "fmt"
+	env.Funcs["Errorf"] = reflect.ValueOf(fmtpkg.Errorf)
+	env.Types["Formatter"] = reflect.TypeOf((*fmtpkg.Formatter)(nil)).Elem()
+	env.Funcs["Fprint"] = reflect.ValueOf(fmtpkg.Fprint)
+	env.Funcs["Fprintf"] = reflect.ValueOf(fmtpkg.Fprintf)
+	env.Funcs["Fprintln"] = reflect.ValueOf(fmtpkg.Fprintln)
+	env.Funcs["Fscan"] = reflect.ValueOf(fmtpkg.Fscan)
+	env.Funcs["Fscanf"] = reflect.ValueOf(fmtpkg.Fscanf)
+	env.Funcs["Fscanln"] = reflect.ValueOf(fmtpkg.Fscanln)
+	env.Types["GoStringer"] = reflect.TypeOf((*fmtpkg.GoStringer)(nil)).Elem()
+	env.Funcs["Print"] = reflect.ValueOf(fmtpkg.Print)
+	env.Funcs["Printf"] = reflect.ValueOf(fmtpkg.Printf)
+	env.Funcs["Println"] = reflect.ValueOf(fmtpkg.Println)
+	env.Funcs["Scan"] = reflect.ValueOf(fmtpkg.Scan)
+	env.Types["ScanState"] = reflect.TypeOf((*fmtpkg.ScanState)(nil)).Elem()
+	env.Funcs["Scanf"] = reflect.ValueOf(fmtpkg.Scanf)
+	env.Funcs["Scanln"] = reflect.ValueOf(fmtpkg.Scanln)
+	env.Types["Scanner"] = reflect.TypeOf((*fmtpkg.Scanner)(nil)).Elem()
+	env.Funcs["Sprint"] = reflect.ValueOf(fmtpkg.Sprint)
+	env.Funcs["Sprintf"] = reflect.ValueOf(fmtpkg.Sprintf)
+	env.Funcs["Sprintln"] = reflect.ValueOf(fmtpkg.Sprintln)
+	env.Funcs["Sscan"] = reflect.ValueOf(fmtpkg.Sscan)
+	env.Funcs["Sscanf"] = reflect.ValueOf(fmtpkg.Sscanf)
+	env.Funcs["Sscanln"] = reflect.ValueOf(fmtpkg.Sscanln)
+	env.Types["State"] = reflect.TypeOf((*fmtpkg.State)(nil)).Elem()
+	env.Types["Stringer"] = reflect.TypeOf((*fmtpkg.Stringer)(nil)).Elem()
+	for _, add := range fmtEnvVersions {
+		add(env)
+	}
+	return env
+}
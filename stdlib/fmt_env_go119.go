@@ -0,0 +1,22 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.19
+
+package stdlib
+
+import (
+	"reflect"
+
+	fmtpkg "fmt"
+	"github.com/0xfaded/eval"
+)
+
+// Declarations of package fmt added in go1.19.
+var _ = func() bool {
+	fmtEnvVersions = append(fmtEnvVersions, func(env *eval.SimpleEnv) {
+		env.Funcs["Append"] = reflect.ValueOf(fmtpkg.Append)
+		env.Funcs["Appendf"] = reflect.ValueOf(fmtpkg.Appendf)
+		env.Funcs["Appendln"] = reflect.ValueOf(fmtpkg.Appendln)
+	})
+	return true
+}()
@@ -0,0 +1,20 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.20
+
+package stdlib
+
+import (
+	"reflect"
+
+	fmtpkg "fmt"
+	"github.com/0xfaded/eval"
+)
+
+// Declarations of package fmt added in go1.20.
+var _ = func() bool {
+	fmtEnvVersions = append(fmtEnvVersions, func(env *eval.SimpleEnv) {
+		env.Funcs["FormatString"] = reflect.ValueOf(fmtpkg.FormatString)
+	})
+	return true
+}()
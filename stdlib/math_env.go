@@ -0,0 +1,118 @@
+// Code generated by genenv; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	mathpkg "math"
+)
+
+func init() {
+	eval.RegisterPackage("math", MathEnv())
+}
+
+// MathEnv returns an Env holding the exported declarations of package math.
+func MathEnv() *eval.SimpleEnv {
+	env := eval.MakeSimpleEnv()
+	env.Path = "math"
+	env.Funcs["Abs"] = reflect.ValueOf(mathpkg.Abs)
+	env.Funcs["Acos"] = reflect.ValueOf(mathpkg.Acos)
+	env.Funcs["Acosh"] = reflect.ValueOf(mathpkg.Acosh)
+	env.Funcs["Asin"] = reflect.ValueOf(mathpkg.Asin)
+	env.Funcs["Asinh"] = reflect.ValueOf(mathpkg.Asinh)
+	env.Funcs["Atan"] = reflect.ValueOf(mathpkg.Atan)
+	env.Funcs["Atan2"] = reflect.ValueOf(mathpkg.Atan2)
+	env.Funcs["Atanh"] = reflect.ValueOf(mathpkg.Atanh)
+	env.Funcs["Cbrt"] = reflect.ValueOf(mathpkg.Cbrt)
+	env.Funcs["Ceil"] = reflect.ValueOf(mathpkg.Ceil)
+	env.Funcs["Copysign"] = reflect.ValueOf(mathpkg.Copysign)
+	env.Funcs["Cos"] = reflect.ValueOf(mathpkg.Cos)
+	env.Funcs["Cosh"] = reflect.ValueOf(mathpkg.Cosh)
+	env.Funcs["Dim"] = reflect.ValueOf(mathpkg.Dim)
+	env.Consts["E"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "271828182845904523536028747135266249775724709369995957496696763/100000000000000000000000000000000000000000000000000000000000000", "0"))
+	env.Funcs["Erf"] = reflect.ValueOf(mathpkg.Erf)
+	env.Funcs["Erfc"] = reflect.ValueOf(mathpkg.Erfc)
+	env.Funcs["Erfcinv"] = reflect.ValueOf(mathpkg.Erfcinv)
+	env.Funcs["Erfinv"] = reflect.ValueOf(mathpkg.Erfinv)
+	env.Funcs["Exp"] = reflect.ValueOf(mathpkg.Exp)
+	env.Funcs["Exp2"] = reflect.ValueOf(mathpkg.Exp2)
+	env.Funcs["Expm1"] = reflect.ValueOf(mathpkg.Expm1)
+	env.Funcs["FMA"] = reflect.ValueOf(mathpkg.FMA)
+	env.Funcs["Float32bits"] = reflect.ValueOf(mathpkg.Float32bits)
+	env.Funcs["Float32frombits"] = reflect.ValueOf(mathpkg.Float32frombits)
+	env.Funcs["Float64bits"] = reflect.ValueOf(mathpkg.Float64bits)
+	env.Funcs["Float64frombits"] = reflect.ValueOf(mathpkg.Float64frombits)
+	env.Funcs["Floor"] = reflect.ValueOf(mathpkg.Floor)
+	env.Funcs["Frexp"] = reflect.ValueOf(mathpkg.Frexp)
+	env.Funcs["Gamma"] = reflect.ValueOf(mathpkg.Gamma)
+	env.Funcs["Hypot"] = reflect.ValueOf(mathpkg.Hypot)
+	env.Funcs["Ilogb"] = reflect.ValueOf(mathpkg.Ilogb)
+	env.Funcs["Inf"] = reflect.ValueOf(mathpkg.Inf)
+	env.Funcs["IsInf"] = reflect.ValueOf(mathpkg.IsInf)
+	env.Funcs["IsNaN"] = reflect.ValueOf(mathpkg.IsNaN)
+	env.Funcs["J0"] = reflect.ValueOf(mathpkg.J0)
+	env.Funcs["J1"] = reflect.ValueOf(mathpkg.J1)
+	env.Funcs["Jn"] = reflect.ValueOf(mathpkg.Jn)
+	env.Funcs["Ldexp"] = reflect.ValueOf(mathpkg.Ldexp)
+	env.Funcs["Lgamma"] = reflect.ValueOf(mathpkg.Lgamma)
+	env.Consts["Ln10"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "23025850929940456840179914546843642076011014886287729760333279/10000000000000000000000000000000000000000000000000000000000000", "0"))
+	env.Consts["Ln2"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "693147180559945309417232121458176568075500134360255254120680009/1000000000000000000000000000000000000000000000000000000000000000", "0"))
+	env.Funcs["Log"] = reflect.ValueOf(mathpkg.Log)
+	env.Funcs["Log10"] = reflect.ValueOf(mathpkg.Log10)
+	env.Consts["Log10E"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "10000000000000000000000000000000000000000000000000000000000000/23025850929940456840179914546843642076011014886287729760333279", "0"))
+	env.Funcs["Log1p"] = reflect.ValueOf(mathpkg.Log1p)
+	env.Funcs["Log2"] = reflect.ValueOf(mathpkg.Log2)
+	env.Consts["Log2E"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "1000000000000000000000000000000000000000000000000000000000000000/693147180559945309417232121458176568075500134360255254120680009", "0"))
+	env.Funcs["Logb"] = reflect.ValueOf(mathpkg.Logb)
+	env.Funcs["Max"] = reflect.ValueOf(mathpkg.Max)
+	env.Consts["MaxFloat32"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "340282346638528859811704183484516925440", "0"))
+	env.Consts["MaxFloat64"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "179769313486231570814527423731704356798070567525844996598917476803157260780028538760589558632766878171540458953514382464234321326889464182768467546703537516986049910576551282076245490090389328944075868508455133942304583236903222948165808559332123348274797826204144723168738177180919299881250404026184124858368", "0"))
+	env.Consts["MaxInt"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "9223372036854775807", "0"))
+	env.Consts["MaxInt16"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "32767", "0"))
+	env.Consts["MaxInt32"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "2147483647", "0"))
+	env.Consts["MaxInt64"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "9223372036854775807", "0"))
+	env.Consts["MaxInt8"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "127", "0"))
+	env.Consts["MaxUint"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "18446744073709551615", "0"))
+	env.Consts["MaxUint16"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "65535", "0"))
+	env.Consts["MaxUint32"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "4294967295", "0"))
+	env.Consts["MaxUint64"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "18446744073709551615", "0"))
+	env.Consts["MaxUint8"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "255", "0"))
+	env.Funcs["Min"] = reflect.ValueOf(mathpkg.Min)
+	env.Consts["MinInt"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "-9223372036854775808", "0"))
+	env.Consts["MinInt16"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "-32768", "0"))
+	env.Consts["MinInt32"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "-2147483648", "0"))
+	env.Consts["MinInt64"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "-9223372036854775808", "0"))
+	env.Consts["MinInt8"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "-128", "0"))
+	env.Funcs["Mod"] = reflect.ValueOf(mathpkg.Mod)
+	env.Funcs["Modf"] = reflect.ValueOf(mathpkg.Modf)
+	env.Funcs["NaN"] = reflect.ValueOf(mathpkg.NaN)
+	env.Funcs["Nextafter"] = reflect.ValueOf(mathpkg.Nextafter)
+	env.Funcs["Nextafter32"] = reflect.ValueOf(mathpkg.Nextafter32)
+	env.Consts["Phi"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "80901699437494742410229341718281905886015458990288143106772431/50000000000000000000000000000000000000000000000000000000000000", "0"))
+	env.Consts["Pi"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "314159265358979323846264338327950288419716939937510582097494459/100000000000000000000000000000000000000000000000000000000000000", "0"))
+	env.Funcs["Pow"] = reflect.ValueOf(mathpkg.Pow)
+	env.Funcs["Pow10"] = reflect.ValueOf(mathpkg.Pow10)
+	env.Funcs["Remainder"] = reflect.ValueOf(mathpkg.Remainder)
+	env.Funcs["Round"] = reflect.ValueOf(mathpkg.Round)
+	env.Funcs["RoundToEven"] = reflect.ValueOf(mathpkg.RoundToEven)
+	env.Funcs["Signbit"] = reflect.ValueOf(mathpkg.Signbit)
+	env.Funcs["Sin"] = reflect.ValueOf(mathpkg.Sin)
+	env.Funcs["Sincos"] = reflect.ValueOf(mathpkg.Sincos)
+	env.Funcs["Sinh"] = reflect.ValueOf(mathpkg.Sinh)
+	env.Consts["SmallestNonzeroFloat32"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "1/713623846352979940529142984724747568191373312", "0"))
+	env.Consts["SmallestNonzeroFloat64"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "1/202402253307310618352495346718917307049556649764142118356901358027430339567995346891960383701437124495187077864316811911389808737385793476867013399940738509921517424276566361364466907742093216341239767678472745068562007483424692698618103355649159556340810056512358769552333414615230502532186327508646006263307707741093494784", "0"))
+	env.Funcs["Sqrt"] = reflect.ValueOf(mathpkg.Sqrt)
+	env.Consts["Sqrt2"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "70710678118654752440084436210484903928483593768847403658833987/50000000000000000000000000000000000000000000000000000000000000", "0"))
+	env.Consts["SqrtE"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "164872127070012814684865078781416357165377610071014801157507931/100000000000000000000000000000000000000000000000000000000000000", "0"))
+	env.Consts["SqrtPhi"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "63600982475703448212621123086874574585780402092004812430832019/50000000000000000000000000000000000000000000000000000000000000", "0"))
+	env.Consts["SqrtPi"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstFloat, "177245385090551602729816748334114518279754945612238712821380779/100000000000000000000000000000000000000000000000000000000000000", "0"))
+	env.Funcs["Tan"] = reflect.ValueOf(mathpkg.Tan)
+	env.Funcs["Tanh"] = reflect.ValueOf(mathpkg.Tanh)
+	env.Funcs["Trunc"] = reflect.ValueOf(mathpkg.Trunc)
+	env.Funcs["Y0"] = reflect.ValueOf(mathpkg.Y0)
+	env.Funcs["Y1"] = reflect.ValueOf(mathpkg.Y1)
+	env.Funcs["Yn"] = reflect.ValueOf(mathpkg.Yn)
+	return env
+}
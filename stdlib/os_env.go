@@ -0,0 +1,142 @@
+// Code generated by genenv; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	ospkg "os"
+)
+
+func init() {
+	eval.RegisterPackage("os", OsEnv())
+}
+
+// Funcs adding the declarations of package os introduced after go1.18,
+// appended by files with build constraints.
+var osEnvVersions []func(*eval.SimpleEnv)
+
+// OsEnv returns an Env holding the exported declarations of package os.
+func OsEnv() *eval.SimpleEnv {
+	env := eval.MakeSimpleEnv()
+	env.Path = "os"
+	env.Vars["Args"] = reflect.ValueOf(&ospkg.Args)
+	env.Funcs["Chdir"] = reflect.ValueOf(ospkg.Chdir)
+	env.Funcs["Chmod"] = reflect.ValueOf(ospkg.Chmod)
+	env.Funcs["Chown"] = reflect.ValueOf(ospkg.Chown)
+	env.Funcs["Chtimes"] = reflect.ValueOf(ospkg.Chtimes)
+	env.Funcs["Clearenv"] = reflect.ValueOf(ospkg.Clearenv)
+	env.Funcs["Create"] = reflect.ValueOf(ospkg.Create)
+	env.Funcs["CreateTemp"] = reflect.ValueOf(ospkg.CreateTemp)
+	env.Consts["DevNull"] = reflect.ValueOf(ospkg.DevNull)
+	env.Types["DirEntry"] = reflect.TypeOf((*ospkg.DirEntry)(nil)).Elem()
+	env.Funcs["DirFS"] = reflect.ValueOf(ospkg.DirFS)
+	env.Funcs["Environ"] = reflect.ValueOf(ospkg.Environ)
+	env.Vars["ErrClosed"] = reflect.ValueOf(&ospkg.ErrClosed)
+	env.Vars["ErrDeadlineExceeded"] = reflect.ValueOf(&ospkg.ErrDeadlineExceeded)
+	env.Vars["ErrExist"] = reflect.ValueOf(&ospkg.ErrExist)
+	env.Vars["ErrInvalid"] = reflect.ValueOf(&ospkg.ErrInvalid)
+	env.Vars["ErrNoDeadline"] = reflect.ValueOf(&ospkg.ErrNoDeadline)
+	env.Vars["ErrNotExist"] = reflect.ValueOf(&ospkg.ErrNotExist)
+	env.Vars["ErrPermission"] = reflect.ValueOf(&ospkg.ErrPermission)
+	env.Vars["ErrProcessDone"] = reflect.ValueOf(&ospkg.ErrProcessDone)
+	env.Funcs["Executable"] = reflect.ValueOf(ospkg.Executable)
+	env.Funcs["Exit"] = reflect.ValueOf(ospkg.Exit)
+	env.Funcs["Expand"] = reflect.ValueOf(ospkg.Expand)
+	env.Funcs["ExpandEnv"] = reflect.ValueOf(ospkg.ExpandEnv)
+	env.Types["File"] = reflect.TypeOf((*ospkg.File)(nil)).Elem()
+	env.Types["FileInfo"] = reflect.TypeOf((*ospkg.FileInfo)(nil)).Elem()
+	env.Types["FileMode"] = reflect.TypeOf((*ospkg.FileMode)(nil)).Elem()
+	env.Funcs["FindProcess"] = reflect.ValueOf(ospkg.FindProcess)
+	env.Funcs["Getegid"] = reflect.ValueOf(ospkg.Getegid)
+	env.Funcs["Getenv"] = reflect.ValueOf(ospkg.Getenv)
+	env.Funcs["Geteuid"] = reflect.ValueOf(ospkg.Geteuid)
+	env.Funcs["Getgid"] = reflect.ValueOf(ospkg.Getgid)
+	env.Funcs["Getgroups"] = reflect.ValueOf(ospkg.Getgroups)
+	env.Funcs["Getpagesize"] = reflect.ValueOf(ospkg.Getpagesize)
+	env.Funcs["Getpid"] = reflect.ValueOf(ospkg.Getpid)
+	env.Funcs["Getppid"] = reflect.ValueOf(ospkg.Getppid)
+	env.Funcs["Getuid"] = reflect.ValueOf(ospkg.Getuid)
+	env.Funcs["Getwd"] = reflect.ValueOf(ospkg.Getwd)
+	env.Funcs["Hostname"] = reflect.ValueOf(ospkg.Hostname)
+	env.Vars["Interrupt"] = reflect.ValueOf(&ospkg.Interrupt)
+	env.Funcs["IsExist"] = reflect.ValueOf(ospkg.IsExist)
+	env.Funcs["IsNotExist"] = reflect.ValueOf(ospkg.IsNotExist)
+	env.Funcs["IsPathSeparator"] = reflect.ValueOf(ospkg.IsPathSeparator)
+	env.Funcs["IsPermission"] = reflect.ValueOf(ospkg.IsPermission)
+	env.Funcs["IsTimeout"] = reflect.ValueOf(ospkg.IsTimeout)
+	env.Vars["Kill"] = reflect.ValueOf(&ospkg.Kill)
+	env.Funcs["Lchown"] = reflect.ValueOf(ospkg.Lchown)
+	env.Funcs["Link"] = reflect.ValueOf(ospkg.Link)
+	env.Types["LinkError"] = reflect.TypeOf((*ospkg.LinkError)(nil)).Elem()
+	env.Funcs["LookupEnv"] = reflect.ValueOf(ospkg.LookupEnv)
+	env.Funcs["Lstat"] = reflect.ValueOf(ospkg.Lstat)
+	env.Funcs["Mkdir"] = reflect.ValueOf(ospkg.Mkdir)
+	env.Funcs["MkdirAll"] = reflect.ValueOf(ospkg.MkdirAll)
+	env.Funcs["MkdirTemp"] = reflect.ValueOf(ospkg.MkdirTemp)
+	env.Consts["ModeAppend"] = reflect.ValueOf(ospkg.ModeAppend)
+	env.Consts["ModeCharDevice"] = reflect.ValueOf(ospkg.ModeCharDevice)
+	env.Consts["ModeDevice"] = reflect.ValueOf(ospkg.ModeDevice)
+	env.Consts["ModeDir"] = reflect.ValueOf(ospkg.ModeDir)
+	env.Consts["ModeExclusive"] = reflect.ValueOf(ospkg.ModeExclusive)
+	env.Consts["ModeIrregular"] = reflect.ValueOf(ospkg.ModeIrregular)
+	env.Consts["ModeNamedPipe"] = reflect.ValueOf(ospkg.ModeNamedPipe)
+	env.Consts["ModePerm"] = reflect.ValueOf(ospkg.ModePerm)
+	env.Consts["ModeSetgid"] = reflect.ValueOf(ospkg.ModeSetgid)
+	env.Consts["ModeSetuid"] = reflect.ValueOf(ospkg.ModeSetuid)
+	env.Consts["ModeSocket"] = reflect.ValueOf(ospkg.ModeSocket)
+	env.Consts["ModeSticky"] = reflect.ValueOf(ospkg.ModeSticky)
+	env.Consts["ModeSymlink"] = reflect.ValueOf(ospkg.ModeSymlink)
+	env.Consts["ModeTemporary"] = reflect.ValueOf(ospkg.ModeTemporary)
+	env.Consts["ModeType"] = reflect.ValueOf(ospkg.ModeType)
+	env.Funcs["NewFile"] = reflect.ValueOf(ospkg.NewFile)
+	env.Funcs["NewSyscallError"] = reflect.ValueOf(ospkg.NewSyscallError)
+	env.Consts["O_APPEND"] = reflect.ValueOf(ospkg.O_APPEND)
+	env.Consts["O_CREATE"] = reflect.ValueOf(ospkg.O_CREATE)
+	env.Consts["O_EXCL"] = reflect.ValueOf(ospkg.O_EXCL)
+	env.Consts["O_RDONLY"] = reflect.ValueOf(ospkg.O_RDONLY)
+	env.Consts["O_RDWR"] = reflect.ValueOf(ospkg.O_RDWR)
+	env.Consts["O_SYNC"] = reflect.ValueOf(ospkg.O_SYNC)
+	env.Consts["O_TRUNC"] = reflect.ValueOf(ospkg.O_TRUNC)
+	env.Consts["O_WRONLY"] = reflect.ValueOf(ospkg.O_WRONLY)
+	env.Funcs["Open"] = reflect.ValueOf(ospkg.Open)
+	env.Funcs["OpenFile"] = reflect.ValueOf(ospkg.OpenFile)
+	env.Types["PathError"] = reflect.TypeOf((*ospkg.PathError)(nil)).Elem()
+	env.Consts["PathListSeparator"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstRune, "58", "0"))
+	env.Consts["PathSeparator"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstRune, "47", "0"))
+	env.Funcs["Pipe"] = reflect.ValueOf(ospkg.Pipe)
+	env.Types["ProcAttr"] = reflect.TypeOf((*ospkg.ProcAttr)(nil)).Elem()
+	env.Types["Process"] = reflect.TypeOf((*ospkg.Process)(nil)).Elem()
+	env.Types["ProcessState"] = reflect.TypeOf((*ospkg.ProcessState)(nil)).Elem()
+	env.Funcs["ReadDir"] = reflect.ValueOf(ospkg.ReadDir)
+	env.Funcs["ReadFile"] = reflect.ValueOf(ospkg.ReadFile)
+	env.Funcs["Readlink"] = reflect.ValueOf(ospkg.Readlink)
+	env.Funcs["Remove"] = reflect.ValueOf(ospkg.Remove)
+	env.Funcs["RemoveAll"] = reflect.ValueOf(ospkg.RemoveAll)
+	env.Funcs["Rename"] = reflect.ValueOf(ospkg.Rename)
+	env.Consts["SEEK_CUR"] = reflect.ValueOf(ospkg.SEEK_CUR)
+	env.Consts["SEEK_END"] = reflect.ValueOf(ospkg.SEEK_END)
+	env.Consts["SEEK_SET"] = reflect.ValueOf(ospkg.SEEK_SET)
+	env.Funcs["SameFile"] = reflect.ValueOf(ospkg.SameFile)
+	env.Funcs["Setenv"] = reflect.ValueOf(ospkg.Setenv)
+	env.Types["Signal"] = reflect.TypeOf((*ospkg.Signal)(nil)).Elem()
+	env.Funcs["StartProcess"] = reflect.ValueOf(ospkg.StartProcess)
+	env.Funcs["Stat"] = reflect.ValueOf(ospkg.Stat)
+	env.Vars["Stderr"] = reflect.ValueOf(&ospkg.Stderr)
+	env.Vars["Stdin"] = reflect.ValueOf(&ospkg.Stdin)
+	env.Vars["Stdout"] = reflect.ValueOf(&ospkg.Stdout)
+	env.Funcs["Symlink"] = reflect.ValueOf(ospkg.Symlink)
+	env.Types["SyscallError"] = reflect.TypeOf((*ospkg.SyscallError)(nil)).Elem()
+	env.Funcs["TempDir"] = reflect.ValueOf(ospkg.TempDir)
+	env.Funcs["Truncate"] = reflect.ValueOf(ospkg.Truncate)
+	env.Funcs["Unsetenv"] = reflect.ValueOf(ospkg.Unsetenv)
+	env.Funcs["UserCacheDir"] = reflect.ValueOf(ospkg.UserCacheDir)
+	env.Funcs["UserConfigDir"] = reflect.ValueOf(ospkg.UserConfigDir)
+	env.Funcs["UserHomeDir"] = reflect.ValueOf(ospkg.UserHomeDir)
+	env.Funcs["WriteFile"] = reflect.ValueOf(ospkg.WriteFile)
+	for _, add := range osEnvVersions {
+		add(env)
+	}
+	return env
+}
@@ -0,0 +1,20 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.23
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	ospkg "os"
+)
+
+// Declarations of package os added in go1.23.
+var _ = func() bool {
+	osEnvVersions = append(osEnvVersions, func(env *eval.SimpleEnv) {
+		env.Funcs["CopyFS"] = reflect.ValueOf(ospkg.CopyFS)
+	})
+	return true
+}()
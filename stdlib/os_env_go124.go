@@ -0,0 +1,22 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.24
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	ospkg "os"
+)
+
+// Declarations of package os added in go1.24.
+var _ = func() bool {
+	osEnvVersions = append(osEnvVersions, func(env *eval.SimpleEnv) {
+		env.Funcs["OpenInRoot"] = reflect.ValueOf(ospkg.OpenInRoot)
+		env.Funcs["OpenRoot"] = reflect.ValueOf(ospkg.OpenRoot)
+		env.Types["Root"] = reflect.TypeOf((*ospkg.Root)(nil)).Elem()
+	})
+	return true
+}()
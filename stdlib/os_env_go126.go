@@ -0,0 +1,20 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.26
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	ospkg "os"
+)
+
+// Declarations of package os added in go1.26.
+var _ = func() bool {
+	osEnvVersions = append(osEnvVersions, func(env *eval.SimpleEnv) {
+		env.Vars["ErrNoHandle"] = reflect.ValueOf(&ospkg.ErrNoHandle)
+	})
+	return true
+}()
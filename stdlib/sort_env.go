@@ -0,0 +1,49 @@
+// Code generated by genenv; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	sortpkg "sort"
+)
+
+func init() {
+	eval.RegisterPackage("sort", SortEnv())
+}
+
+// Funcs adding the declarations of package sort introduced after go1.18,
+// appended by files with build constraints.
+var sortEnvVersions []func(*eval.SimpleEnv)
+
+// SortEnv returns an Env holding the exported declarations of package sort.
+func SortEnv() *eval.SimpleEnv {
+	env := eval.MakeSimpleEnv()
+	env.Path = "sort"
+	env.Types["Float64Slice"] = reflect.TypeOf((*sortpkg.Float64Slice)(nil)).Elem()
+	env.Funcs["Float64s"] = reflect.ValueOf(sortpkg.Float64s)
+	env.Funcs["Float64sAreSorted"] = reflect.ValueOf(sortpkg.Float64sAreSorted)
+	env.Types["IntSlice"] = reflect.TypeOf((*sortpkg.IntSlice)(nil)).Elem()
+	env.Types["Interface"] = reflect.TypeOf((*sortpkg.Interface)(nil)).Elem()
+	env.Funcs["Ints"] = reflect.ValueOf(sortpkg.Ints)
+	env.Funcs["IntsAreSorted"] = reflect.ValueOf(sortpkg.IntsAreSorted)
+	env.Funcs["IsSorted"] = reflect.ValueOf(sortpkg.IsSorted)
+	env.Funcs["Reverse"] = reflect.ValueOf(sortpkg.Reverse)
+	env.Funcs["Search"] = reflect.ValueOf(sortpkg.Search)
+	env.Funcs["SearchFloat64s"] = reflect.ValueOf(sortpkg.SearchFloat64s)
+	env.Funcs["SearchInts"] = reflect.ValueOf(sortpkg.SearchInts)
+	env.Funcs["SearchStrings"] = reflect.ValueOf(sortpkg.SearchStrings)
+	env.Funcs["Slice"] = reflect.ValueOf(sortpkg.Slice)
+	env.Funcs["SliceIsSorted"] = reflect.ValueOf(sortpkg.SliceIsSorted)
+	env.Funcs["SliceStable"] = reflect.ValueOf(sortpkg.SliceStable)
+	env.Funcs["Sort"] = reflect.ValueOf(sortpkg.Sort)
+	env.Funcs["Stable"] = reflect.ValueOf(sortpkg.Stable)
+	env.Types["StringSlice"] = reflect.TypeOf((*sortpkg.StringSlice)(nil)).Elem()
+	env.Funcs["Strings"] = reflect.ValueOf(sortpkg.Strings)
+	env.Funcs["StringsAreSorted"] = reflect.ValueOf(sortpkg.StringsAreSorted)
+	for _, add := range sortEnvVersions {
+		add(env)
+	}
+	return env
+}
@@ -0,0 +1,20 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.19
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	sortpkg "sort"
+)
+
+// Declarations of package sort added in go1.19.
+var _ = func() bool {
+	sortEnvVersions = append(sortEnvVersions, func(env *eval.SimpleEnv) {
+		env.Funcs["Find"] = reflect.ValueOf(sortpkg.Find)
+	})
+	return true
+}()
@@ -0,0 +1,58 @@
+// Package stdlib provides Envs for a curated set of standard library
+// packages. Importing it, even for side effects only, registers each Env
+// with eval.DefaultRegistry under its import path:
+//
+//	import _ "github.com/0xfaded/eval/stdlib"
+//
+// The Envs are generated by genenv. Declarations added after go1.18 are
+// declared in files with build constraints, so each Env holds exactly the
+// declarations of the Go version it is built with.
+package stdlib
+
+//go:generate go run ../genenv -go go1.18 -o bytes_env.go bytes
+//go:generate go run ../genenv -go go1.18 -o errors_env.go errors
+//go:generate go run ../genenv -go go1.18 -o fmt_env.go fmt
+//go:generate go run ../genenv -go go1.18 -o math_env.go math
+//go:generate go run ../genenv -go go1.18 -o os_env.go os
+//go:generate go run ../genenv -go go1.18 -o sort_env.go sort
+//go:generate go run ../genenv -go go1.18 -o strconv_env.go strconv
+//go:generate go run ../genenv -go go1.18 -o strings_env.go strings
+//go:generate go run ../genenv -go go1.18 -o time_env.go time
+//go:generate go run ../genenv -go go1.18 -o unicode_env.go unicode
+
+import (
+	"github.com/0xfaded/eval"
+)
+
+// The Env funcs of the bundled packages, keyed by import path.
+var envFuncs = map[string]func() *eval.SimpleEnv{
+	"bytes":   BytesEnv,
+	"errors":  ErrorsEnv,
+	"fmt":     FmtEnv,
+	"math":    MathEnv,
+	"os":      OsEnv,
+	"sort":    SortEnv,
+	"strconv": StrconvEnv,
+	"strings": StringsEnv,
+	"time":    TimeEnv,
+	"unicode": UnicodeEnv,
+}
+
+// Env returns a root Env in which every bundled package is declared under
+// its import path, so that env.Pkg("strings") returns the Env of package
+// strings and expressions such as strings.ToUpper("go") are evaluated
+// without an import declaration.
+func Env() *eval.SimpleEnv {
+	env := eval.MakeSimpleEnv()
+	for path, f := range envFuncs {
+		env.Pkgs[path] = f()
+	}
+	return env
+}
+
+// Register a new Env of each bundled package with r.
+func Register(r *eval.Registry) {
+	for path, f := range envFuncs {
+		r.Register(path, f())
+	}
+}
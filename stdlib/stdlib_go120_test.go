@@ -0,0 +1,14 @@
+//go:build go1.20
+
+package stdlib
+
+import (
+	"testing"
+)
+
+func TestVersionedDeclarations(t *testing.T) {
+	// Declared by strings_env_go120.go
+	if !StringsEnv().Func("CutPrefix").IsValid() {
+		t.Fatalf("CutPrefix is undefined")
+	}
+}
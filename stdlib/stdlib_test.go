@@ -0,0 +1,68 @@
+package stdlib
+
+import (
+	"go/parser"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/0xfaded/eval"
+)
+
+func evalExpr(t *testing.T, expr string, env eval.Env) interface{} {
+	e, err := parser.ParseExpr(expr)
+	if err != nil {
+		t.Fatalf("Failed to parse %s: %v", expr, err)
+	}
+	cexpr, errs := eval.CheckExpr(e, env)
+	if errs != nil {
+		t.Fatalf("Failed to check %s: %v", expr, errs)
+	}
+	results, err := eval.EvalExpr(cexpr, env)
+	if err != nil {
+		t.Fatalf("Failed to eval %s: %v", expr, err)
+	} else if results == nil || len(results) != 1 {
+		t.Fatalf("Expected a single result for %s", expr)
+	}
+	return results[0].Interface()
+}
+
+func TestEnv(t *testing.T) {
+	env := Env()
+	for path := range envFuncs {
+		if pkg, ok := env.Pkg(path).(*eval.SimpleEnv); !ok || pkg.Path != path {
+			t.Errorf("Env.Pkg(%q) is not the bundled package", path)
+		}
+	}
+	for expr, expected := range map[string]interface{}{
+		`strings.ToUpper("go")`: "GO",
+		`strconv.Itoa(42) + fmt.Sprint(1.5)`: "421.5",
+		`math.MaxUint64 / 2 > math.MaxInt32`: true,
+		`int(math.Floor(math.Pi * 100))`: 314,
+		`bytes.Equal([]byte("a"), []byte{'a'})`: true,
+		`unicode.IsUpper('G')`: true,
+		`time.Duration(90) * time.Second`: 90 * time.Second,
+		`errors.New("x").Error()`: "x",
+		`sort.SearchInts([]int{1, 3, 5}, 3)`: 1,
+	} {
+		if actual := evalExpr(t, expr, env); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s = %v, expected %v", expr, actual, expected)
+		}
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	env := eval.MakeSimpleEnv()
+	if _, _, errs := eval.Interpret(`import ("strings"; str "strconv")`, env); errs != nil {
+		t.Fatalf("Failed to import %v", errs)
+	}
+	if actual := evalExpr(t, `strings.Repeat(str.Quote("a"), 2)`, env); actual != `"a""a"` {
+		t.Fatalf("Wrong result %v", actual)
+	}
+
+	r := eval.NewRegistry()
+	Register(r)
+	if r.Lookup("os") == nil || r.Lookup("net") != nil {
+		t.Fatalf("Wrong packages registered")
+	}
+}
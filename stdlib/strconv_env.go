@@ -0,0 +1,59 @@
+// Code generated by genenv; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	strconvpkg "strconv"
+)
+
+func init() {
+	eval.RegisterPackage("strconv", StrconvEnv())
+}
+
+// StrconvEnv returns an Env holding the exported declarations of package strconv.
+func StrconvEnv() *eval.SimpleEnv {
+	env := eval.MakeSimpleEnv()
+	env.Path = "strconv"
+	env.Funcs["AppendBool"] = reflect.ValueOf(strconvpkg.AppendBool)
+	env.Funcs["AppendFloat"] = reflect.ValueOf(strconvpkg.AppendFloat)
+	env.Funcs["AppendInt"] = reflect.ValueOf(strconvpkg.AppendInt)
+	env.Funcs["AppendQuote"] = reflect.ValueOf(strconvpkg.AppendQuote)
+	env.Funcs["AppendQuoteRune"] = reflect.ValueOf(strconvpkg.AppendQuoteRune)
+	env.Funcs["AppendQuoteRuneToASCII"] = reflect.ValueOf(strconvpkg.AppendQuoteRuneToASCII)
+	env.Funcs["AppendQuoteRuneToGraphic"] = reflect.ValueOf(strconvpkg.AppendQuoteRuneToGraphic)
+	env.Funcs["AppendQuoteToASCII"] = reflect.ValueOf(strconvpkg.AppendQuoteToASCII)
+	env.Funcs["AppendQuoteToGraphic"] = reflect.ValueOf(strconvpkg.AppendQuoteToGraphic)
+	env.Funcs["AppendUint"] = reflect.ValueOf(strconvpkg.AppendUint)
+	env.Funcs["Atoi"] = reflect.ValueOf(strconvpkg.Atoi)
+	env.Funcs["CanBackquote"] = reflect.ValueOf(strconvpkg.CanBackquote)
+	env.Vars["ErrRange"] = reflect.ValueOf(&strconvpkg.ErrRange)
+	env.Vars["ErrSyntax"] = reflect.ValueOf(&strconvpkg.ErrSyntax)
+	env.Funcs["FormatBool"] = reflect.ValueOf(strconvpkg.FormatBool)
+	env.Funcs["FormatComplex"] = reflect.ValueOf(strconvpkg.FormatComplex)
+	env.Funcs["FormatFloat"] = reflect.ValueOf(strconvpkg.FormatFloat)
+	env.Funcs["FormatInt"] = reflect.ValueOf(strconvpkg.FormatInt)
+	env.Funcs["FormatUint"] = reflect.ValueOf(strconvpkg.FormatUint)
+	env.Consts["IntSize"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "64", "0"))
+	env.Funcs["IsGraphic"] = reflect.ValueOf(strconvpkg.IsGraphic)
+	env.Funcs["IsPrint"] = reflect.ValueOf(strconvpkg.IsPrint)
+	env.Funcs["Itoa"] = reflect.ValueOf(strconvpkg.Itoa)
+	env.Types["NumError"] = reflect.TypeOf((*strconvpkg.NumError)(nil)).Elem()
+	env.Funcs["ParseBool"] = reflect.ValueOf(strconvpkg.ParseBool)
+	env.Funcs["ParseComplex"] = reflect.ValueOf(strconvpkg.ParseComplex)
+	env.Funcs["ParseFloat"] = reflect.ValueOf(strconvpkg.ParseFloat)
+	env.Funcs["ParseInt"] = reflect.ValueOf(strconvpkg.ParseInt)
+	env.Funcs["ParseUint"] = reflect.ValueOf(strconvpkg.ParseUint)
+	env.Funcs["Quote"] = reflect.ValueOf(strconvpkg.Quote)
+	env.Funcs["QuoteRune"] = reflect.ValueOf(strconvpkg.QuoteRune)
+	env.Funcs["QuoteRuneToASCII"] = reflect.ValueOf(strconvpkg.QuoteRuneToASCII)
+	env.Funcs["QuoteRuneToGraphic"] = reflect.ValueOf(strconvpkg.QuoteRuneToGraphic)
+	env.Funcs["QuoteToASCII"] = reflect.ValueOf(strconvpkg.QuoteToASCII)
+	env.Funcs["QuoteToGraphic"] = reflect.ValueOf(strconvpkg.QuoteToGraphic)
+	env.Funcs["QuotedPrefix"] = reflect.ValueOf(strconvpkg.QuotedPrefix)
+	env.Funcs["Unquote"] = reflect.ValueOf(strconvpkg.Unquote)
+	env.Funcs["UnquoteChar"] = reflect.ValueOf(strconvpkg.UnquoteChar)
+	return env
+}
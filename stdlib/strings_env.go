@@ -0,0 +1,80 @@
+// Code generated by genenv; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	stringspkg "strings"
+)
+
+func init() {
+	eval.RegisterPackage("strings", StringsEnv())
+}
+
+// Funcs adding the declarations of package strings introduced after go1.18,
+// appended by files with build constraints.
+var stringsEnvVersions []func(*eval.SimpleEnv)
+
+// StringsEnv returns an Env holding the exported declarations of package strings.
+func StringsEnv() *eval.SimpleEnv {
+	env := eval.MakeSimpleEnv()
+	env.Path = "strings"
+	env.Types["Builder"] = reflect.TypeOf((*stringspkg.Builder)(nil)).Elem()
+	env.Funcs["Clone"] = reflect.ValueOf(stringspkg.Clone)
+	env.Funcs["Compare"] = reflect.ValueOf(stringspkg.Compare)
+	env.Funcs["Contains"] = reflect.ValueOf(stringspkg.Contains)
+	env.Funcs["ContainsAny"] = reflect.ValueOf(stringspkg.ContainsAny)
+	env.Funcs["ContainsRune"] = reflect.ValueOf(stringspkg.ContainsRune)
+	env.Funcs["Count"] = reflect.ValueOf(stringspkg.Count)
+	env.Funcs["Cut"] = reflect.ValueOf(stringspkg.Cut)
+	env.Funcs["EqualFold"] = reflect.ValueOf(stringspkg.EqualFold)
+	env.Funcs["Fields"] = reflect.ValueOf(stringspkg.Fields)
+	env.Funcs["FieldsFunc"] = reflect.ValueOf(stringspkg.FieldsFunc)
+	env.Funcs["HasPrefix"] = reflect.ValueOf(stringspkg.HasPrefix)
+	env.Funcs["HasSuffix"] = reflect.ValueOf(stringspkg.HasSuffix)
+	env.Funcs["Index"] = reflect.ValueOf(stringspkg.Index)
+	env.Funcs["IndexAny"] = reflect.ValueOf(stringspkg.IndexAny)
+	env.Funcs["IndexByte"] = reflect.ValueOf(stringspkg.IndexByte)
+	env.Funcs["IndexFunc"] = reflect.ValueOf(stringspkg.IndexFunc)
+	env.Funcs["IndexRune"] = reflect.ValueOf(stringspkg.IndexRune)
+	env.Funcs["Join"] = reflect.ValueOf(stringspkg.Join)
+	env.Funcs["LastIndex"] = reflect.ValueOf(stringspkg.LastIndex)
+	env.Funcs["LastIndexAny"] = reflect.ValueOf(stringspkg.LastIndexAny)
+	env.Funcs["LastIndexByte"] = reflect.ValueOf(stringspkg.LastIndexByte)
+	env.Funcs["LastIndexFunc"] = reflect.ValueOf(stringspkg.LastIndexFunc)
+	env.Funcs["Map"] = reflect.ValueOf(stringspkg.Map)
+	env.Funcs["NewReader"] = reflect.ValueOf(stringspkg.NewReader)
+	env.Funcs["NewReplacer"] = reflect.ValueOf(stringspkg.NewReplacer)
+	env.Types["Reader"] = reflect.TypeOf((*stringspkg.Reader)(nil)).Elem()
+	env.Funcs["Repeat"] = reflect.ValueOf(stringspkg.Repeat)
+	env.Funcs["Replace"] = reflect.ValueOf(stringspkg.Replace)
+	env.Funcs["ReplaceAll"] = reflect.ValueOf(stringspkg.ReplaceAll)
+	env.Types["Replacer"] = reflect.TypeOf((*stringspkg.Replacer)(nil)).Elem()
+	env.Funcs["Split"] = reflect.ValueOf(stringspkg.Split)
+	env.Funcs["SplitAfter"] = reflect.ValueOf(stringspkg.SplitAfter)
+	env.Funcs["SplitAfterN"] = reflect.ValueOf(stringspkg.SplitAfterN)
+	env.Funcs["SplitN"] = reflect.ValueOf(stringspkg.SplitN)
+	env.Funcs["Title"] = reflect.ValueOf(stringspkg.Title)
+	env.Funcs["ToLower"] = reflect.ValueOf(stringspkg.ToLower)
+	env.Funcs["ToLowerSpecial"] = reflect.ValueOf(stringspkg.ToLowerSpecial)
+	env.Funcs["ToTitle"] = reflect.ValueOf(stringspkg.ToTitle)
+	env.Funcs["ToTitleSpecial"] = reflect.ValueOf(stringspkg.ToTitleSpecial)
+	env.Funcs["ToUpper"] = reflect.ValueOf(stringspkg.ToUpper)
+	env.Funcs["ToUpperSpecial"] = reflect.ValueOf(stringspkg.ToUpperSpecial)
+	env.Funcs["ToValidUTF8"] = reflect.ValueOf(stringspkg.ToValidUTF8)
+	env.Funcs["Trim"] = reflect.ValueOf(stringspkg.Trim)
+	env.Funcs["TrimFunc"] = reflect.ValueOf(stringspkg.TrimFunc)
+	env.Funcs["TrimLeft"] = reflect.ValueOf(stringspkg.TrimLeft)
+	env.Funcs["TrimLeftFunc"] = reflect.ValueOf(stringspkg.TrimLeftFunc)
+	env.Funcs["TrimPrefix"] = reflect.ValueOf(stringspkg.TrimPrefix)
+	env.Funcs["TrimRight"] = reflect.ValueOf(stringspkg.TrimRight)
+	env.Funcs["TrimRightFunc"] = reflect.ValueOf(stringspkg.TrimRightFunc)
+	env.Funcs["TrimSpace"] = reflect.ValueOf(stringspkg.TrimSpace)
+	env.Funcs["TrimSuffix"] = reflect.ValueOf(stringspkg.TrimSuffix)
+	for _, add := range stringsEnvVersions {
+		add(env)
+	}
+	return env
+}
@@ -0,0 +1,21 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.20
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	stringspkg "strings"
+)
+
+// Declarations of package strings added in go1.20.
+var _ = func() bool {
+	stringsEnvVersions = append(stringsEnvVersions, func(env *eval.SimpleEnv) {
+		env.Funcs["CutPrefix"] = reflect.ValueOf(stringspkg.CutPrefix)
+		env.Funcs["CutSuffix"] = reflect.ValueOf(stringspkg.CutSuffix)
+	})
+	return true
+}()
@@ -0,0 +1,24 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.24
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	stringspkg "strings"
+)
+
+// Declarations of package strings added in go1.24.
+var _ = func() bool {
+	stringsEnvVersions = append(stringsEnvVersions, func(env *eval.SimpleEnv) {
+		env.Funcs["FieldsFuncSeq"] = reflect.ValueOf(stringspkg.FieldsFuncSeq)
+		env.Funcs["FieldsSeq"] = reflect.ValueOf(stringspkg.FieldsSeq)
+		env.Funcs["Lines"] = reflect.ValueOf(stringspkg.Lines)
+		env.Funcs["SplitAfterSeq"] = reflect.ValueOf(stringspkg.SplitAfterSeq)
+		env.Funcs["SplitSeq"] = reflect.ValueOf(stringspkg.SplitSeq)
+	})
+	return true
+}()
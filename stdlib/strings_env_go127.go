@@ -0,0 +1,20 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.27
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	stringspkg "strings"
+)
+
+// Declarations of package strings added in go1.27.
+var _ = func() bool {
+	stringsEnvVersions = append(stringsEnvVersions, func(env *eval.SimpleEnv) {
+		env.Funcs["CutLast"] = reflect.ValueOf(stringspkg.CutLast)
+	})
+	return true
+}()
@@ -0,0 +1,98 @@
+// Code generated by genenv; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	timepkg "time"
+)
+
+func init() {
+	eval.RegisterPackage("time", TimeEnv())
+}
+
+// Funcs adding the declarations of package time introduced after go1.18,
+// appended by files with build constraints.
+var timeEnvVersions []func(*eval.SimpleEnv)
+
+// TimeEnv returns an Env holding the exported declarations of package time.
+func TimeEnv() *eval.SimpleEnv {
+	env := eval.MakeSimpleEnv()
+	env.Path = "time"
+	env.Consts["ANSIC"] = reflect.ValueOf(timepkg.ANSIC)
+	env.Funcs["After"] = reflect.ValueOf(timepkg.After)
+	env.Funcs["AfterFunc"] = reflect.ValueOf(timepkg.AfterFunc)
+	env.Consts["April"] = reflect.ValueOf(timepkg.April)
+	env.Consts["August"] = reflect.ValueOf(timepkg.August)
+	env.Funcs["Date"] = reflect.ValueOf(timepkg.Date)
+	env.Consts["December"] = reflect.ValueOf(timepkg.December)
+	env.Types["Duration"] = reflect.TypeOf((*timepkg.Duration)(nil)).Elem()
+	env.Consts["February"] = reflect.ValueOf(timepkg.February)
+	env.Funcs["FixedZone"] = reflect.ValueOf(timepkg.FixedZone)
+	env.Consts["Friday"] = reflect.ValueOf(timepkg.Friday)
+	env.Consts["Hour"] = reflect.ValueOf(timepkg.Hour)
+	env.Consts["January"] = reflect.ValueOf(timepkg.January)
+	env.Consts["July"] = reflect.ValueOf(timepkg.July)
+	env.Consts["June"] = reflect.ValueOf(timepkg.June)
+	env.Consts["Kitchen"] = reflect.ValueOf(timepkg.Kitchen)
+	env.Consts["Layout"] = reflect.ValueOf(timepkg.Layout)
+	env.Funcs["LoadLocation"] = reflect.ValueOf(timepkg.LoadLocation)
+	env.Funcs["LoadLocationFromTZData"] = reflect.ValueOf(timepkg.LoadLocationFromTZData)
+	env.Vars["Local"] = reflect.ValueOf(&timepkg.Local)
+	env.Types["Location"] = reflect.TypeOf((*timepkg.Location)(nil)).Elem()
+	env.Consts["March"] = reflect.ValueOf(timepkg.March)
+	env.Consts["May"] = reflect.ValueOf(timepkg.May)
+	env.Consts["Microsecond"] = reflect.ValueOf(timepkg.Microsecond)
+	env.Consts["Millisecond"] = reflect.ValueOf(timepkg.Millisecond)
+	env.Consts["Minute"] = reflect.ValueOf(timepkg.Minute)
+	env.Consts["Monday"] = reflect.ValueOf(timepkg.Monday)
+	env.Types["Month"] = reflect.TypeOf((*timepkg.Month)(nil)).Elem()
+	env.Consts["Nanosecond"] = reflect.ValueOf(timepkg.Nanosecond)
+	env.Funcs["NewTicker"] = reflect.ValueOf(timepkg.NewTicker)
+	env.Funcs["NewTimer"] = reflect.ValueOf(timepkg.NewTimer)
+	env.Consts["November"] = reflect.ValueOf(timepkg.November)
+	env.Funcs["Now"] = reflect.ValueOf(timepkg.Now)
+	env.Consts["October"] = reflect.ValueOf(timepkg.October)
+	env.Funcs["Parse"] = reflect.ValueOf(timepkg.Parse)
+	env.Funcs["ParseDuration"] = reflect.ValueOf(timepkg.ParseDuration)
+	env.Types["ParseError"] = reflect.TypeOf((*timepkg.ParseError)(nil)).Elem()
+	env.Funcs["ParseInLocation"] = reflect.ValueOf(timepkg.ParseInLocation)
+	env.Consts["RFC1123"] = reflect.ValueOf(timepkg.RFC1123)
+	env.Consts["RFC1123Z"] = reflect.ValueOf(timepkg.RFC1123Z)
+	env.Consts["RFC3339"] = reflect.ValueOf(timepkg.RFC3339)
+	env.Consts["RFC3339Nano"] = reflect.ValueOf(timepkg.RFC3339Nano)
+	env.Consts["RFC822"] = reflect.ValueOf(timepkg.RFC822)
+	env.Consts["RFC822Z"] = reflect.ValueOf(timepkg.RFC822Z)
+	env.Consts["RFC850"] = reflect.ValueOf(timepkg.RFC850)
+	env.Consts["RubyDate"] = reflect.ValueOf(timepkg.RubyDate)
+	env.Consts["Saturday"] = reflect.ValueOf(timepkg.Saturday)
+	env.Consts["Second"] = reflect.ValueOf(timepkg.Second)
+	env.Consts["September"] = reflect.ValueOf(timepkg.September)
+	env.Funcs["Since"] = reflect.ValueOf(timepkg.Since)
+	env.Funcs["Sleep"] = reflect.ValueOf(timepkg.Sleep)
+	env.Consts["Stamp"] = reflect.ValueOf(timepkg.Stamp)
+	env.Consts["StampMicro"] = reflect.ValueOf(timepkg.StampMicro)
+	env.Consts["StampMilli"] = reflect.ValueOf(timepkg.StampMilli)
+	env.Consts["StampNano"] = reflect.ValueOf(timepkg.StampNano)
+	env.Consts["Sunday"] = reflect.ValueOf(timepkg.Sunday)
+	env.Consts["Thursday"] = reflect.ValueOf(timepkg.Thursday)
+	env.Funcs["Tick"] = reflect.ValueOf(timepkg.Tick)
+	env.Types["Ticker"] = reflect.TypeOf((*timepkg.Ticker)(nil)).Elem()
+	env.Types["Time"] = reflect.TypeOf((*timepkg.Time)(nil)).Elem()
+	env.Types["Timer"] = reflect.TypeOf((*timepkg.Timer)(nil)).Elem()
+	env.Consts["Tuesday"] = reflect.ValueOf(timepkg.Tuesday)
+	env.Vars["UTC"] = reflect.ValueOf(&timepkg.UTC)
+	env.Funcs["Unix"] = reflect.ValueOf(timepkg.Unix)
+	env.Consts["UnixDate"] = reflect.ValueOf(timepkg.UnixDate)
+	env.Funcs["UnixMicro"] = reflect.ValueOf(timepkg.UnixMicro)
+	env.Funcs["UnixMilli"] = reflect.ValueOf(timepkg.UnixMilli)
+	env.Funcs["Until"] = reflect.ValueOf(timepkg.Until)
+	env.Consts["Wednesday"] = reflect.ValueOf(timepkg.Wednesday)
+	env.Types["Weekday"] = reflect.TypeOf((*timepkg.Weekday)(nil)).Elem()
+	for _, add := range timeEnvVersions {
+		add(env)
+	}
+	return env
+}
@@ -0,0 +1,22 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.20
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	timepkg "time"
+)
+
+// Declarations of package time added in go1.20.
+var _ = func() bool {
+	timeEnvVersions = append(timeEnvVersions, func(env *eval.SimpleEnv) {
+		env.Consts["DateOnly"] = reflect.ValueOf(timepkg.DateOnly)
+		env.Consts["DateTime"] = reflect.ValueOf(timepkg.DateTime)
+		env.Consts["TimeOnly"] = reflect.ValueOf(timepkg.TimeOnly)
+	})
+	return true
+}()
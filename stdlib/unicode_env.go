@@ -0,0 +1,312 @@
+// Code generated by genenv; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	unicodepkg "unicode"
+)
+
+func init() {
+	eval.RegisterPackage("unicode", UnicodeEnv())
+}
+
+// Funcs adding the declarations of package unicode introduced after go1.18,
+// appended by files with build constraints.
+var unicodeEnvVersions []func(*eval.SimpleEnv)
+
+// UnicodeEnv returns an Env holding the exported declarations of package unicode.
+func UnicodeEnv() *eval.SimpleEnv {
+	env := eval.MakeSimpleEnv()
+	env.Path = "unicode"
+	env.Vars["ASCII_Hex_Digit"] = reflect.ValueOf(&unicodepkg.ASCII_Hex_Digit)
+	env.Vars["Adlam"] = reflect.ValueOf(&unicodepkg.Adlam)
+	env.Vars["Ahom"] = reflect.ValueOf(&unicodepkg.Ahom)
+	env.Vars["Anatolian_Hieroglyphs"] = reflect.ValueOf(&unicodepkg.Anatolian_Hieroglyphs)
+	env.Vars["Arabic"] = reflect.ValueOf(&unicodepkg.Arabic)
+	env.Vars["Armenian"] = reflect.ValueOf(&unicodepkg.Armenian)
+	env.Vars["Avestan"] = reflect.ValueOf(&unicodepkg.Avestan)
+	env.Vars["AzeriCase"] = reflect.ValueOf(&unicodepkg.AzeriCase)
+	env.Vars["Balinese"] = reflect.ValueOf(&unicodepkg.Balinese)
+	env.Vars["Bamum"] = reflect.ValueOf(&unicodepkg.Bamum)
+	env.Vars["Bassa_Vah"] = reflect.ValueOf(&unicodepkg.Bassa_Vah)
+	env.Vars["Batak"] = reflect.ValueOf(&unicodepkg.Batak)
+	env.Vars["Bengali"] = reflect.ValueOf(&unicodepkg.Bengali)
+	env.Vars["Bhaiksuki"] = reflect.ValueOf(&unicodepkg.Bhaiksuki)
+	env.Vars["Bidi_Control"] = reflect.ValueOf(&unicodepkg.Bidi_Control)
+	env.Vars["Bopomofo"] = reflect.ValueOf(&unicodepkg.Bopomofo)
+	env.Vars["Brahmi"] = reflect.ValueOf(&unicodepkg.Brahmi)
+	env.Vars["Braille"] = reflect.ValueOf(&unicodepkg.Braille)
+	env.Vars["Buginese"] = reflect.ValueOf(&unicodepkg.Buginese)
+	env.Vars["Buhid"] = reflect.ValueOf(&unicodepkg.Buhid)
+	env.Vars["C"] = reflect.ValueOf(&unicodepkg.C)
+	env.Vars["Canadian_Aboriginal"] = reflect.ValueOf(&unicodepkg.Canadian_Aboriginal)
+	env.Vars["Carian"] = reflect.ValueOf(&unicodepkg.Carian)
+	env.Types["CaseRange"] = reflect.TypeOf((*unicodepkg.CaseRange)(nil)).Elem()
+	env.Vars["CaseRanges"] = reflect.ValueOf(&unicodepkg.CaseRanges)
+	env.Vars["Categories"] = reflect.ValueOf(&unicodepkg.Categories)
+	env.Vars["Caucasian_Albanian"] = reflect.ValueOf(&unicodepkg.Caucasian_Albanian)
+	env.Vars["Cc"] = reflect.ValueOf(&unicodepkg.Cc)
+	env.Vars["Cf"] = reflect.ValueOf(&unicodepkg.Cf)
+	env.Vars["Chakma"] = reflect.ValueOf(&unicodepkg.Chakma)
+	env.Vars["Cham"] = reflect.ValueOf(&unicodepkg.Cham)
+	env.Vars["Cherokee"] = reflect.ValueOf(&unicodepkg.Cherokee)
+	env.Vars["Chorasmian"] = reflect.ValueOf(&unicodepkg.Chorasmian)
+	env.Vars["Co"] = reflect.ValueOf(&unicodepkg.Co)
+	env.Vars["Common"] = reflect.ValueOf(&unicodepkg.Common)
+	env.Vars["Coptic"] = reflect.ValueOf(&unicodepkg.Coptic)
+	env.Vars["Cs"] = reflect.ValueOf(&unicodepkg.Cs)
+	env.Vars["Cuneiform"] = reflect.ValueOf(&unicodepkg.Cuneiform)
+	env.Vars["Cypriot"] = reflect.ValueOf(&unicodepkg.Cypriot)
+	env.Vars["Cyrillic"] = reflect.ValueOf(&unicodepkg.Cyrillic)
+	env.Vars["Dash"] = reflect.ValueOf(&unicodepkg.Dash)
+	env.Vars["Deprecated"] = reflect.ValueOf(&unicodepkg.Deprecated)
+	env.Vars["Deseret"] = reflect.ValueOf(&unicodepkg.Deseret)
+	env.Vars["Devanagari"] = reflect.ValueOf(&unicodepkg.Devanagari)
+	env.Vars["Diacritic"] = reflect.ValueOf(&unicodepkg.Diacritic)
+	env.Vars["Digit"] = reflect.ValueOf(&unicodepkg.Digit)
+	env.Vars["Dives_Akuru"] = reflect.ValueOf(&unicodepkg.Dives_Akuru)
+	env.Vars["Dogra"] = reflect.ValueOf(&unicodepkg.Dogra)
+	env.Vars["Duployan"] = reflect.ValueOf(&unicodepkg.Duployan)
+	env.Vars["Egyptian_Hieroglyphs"] = reflect.ValueOf(&unicodepkg.Egyptian_Hieroglyphs)
+	env.Vars["Elbasan"] = reflect.ValueOf(&unicodepkg.Elbasan)
+	env.Vars["Elymaic"] = reflect.ValueOf(&unicodepkg.Elymaic)
+	env.Vars["Ethiopic"] = reflect.ValueOf(&unicodepkg.Ethiopic)
+	env.Vars["Extender"] = reflect.ValueOf(&unicodepkg.Extender)
+	env.Vars["FoldCategory"] = reflect.ValueOf(&unicodepkg.FoldCategory)
+	env.Vars["FoldScript"] = reflect.ValueOf(&unicodepkg.FoldScript)
+	env.Vars["Georgian"] = reflect.ValueOf(&unicodepkg.Georgian)
+	env.Vars["Glagolitic"] = reflect.ValueOf(&unicodepkg.Glagolitic)
+	env.Vars["Gothic"] = reflect.ValueOf(&unicodepkg.Gothic)
+	env.Vars["Grantha"] = reflect.ValueOf(&unicodepkg.Grantha)
+	env.Vars["GraphicRanges"] = reflect.ValueOf(&unicodepkg.GraphicRanges)
+	env.Vars["Greek"] = reflect.ValueOf(&unicodepkg.Greek)
+	env.Vars["Gujarati"] = reflect.ValueOf(&unicodepkg.Gujarati)
+	env.Vars["Gunjala_Gondi"] = reflect.ValueOf(&unicodepkg.Gunjala_Gondi)
+	env.Vars["Gurmukhi"] = reflect.ValueOf(&unicodepkg.Gurmukhi)
+	env.Vars["Han"] = reflect.ValueOf(&unicodepkg.Han)
+	env.Vars["Hangul"] = reflect.ValueOf(&unicodepkg.Hangul)
+	env.Vars["Hanifi_Rohingya"] = reflect.ValueOf(&unicodepkg.Hanifi_Rohingya)
+	env.Vars["Hanunoo"] = reflect.ValueOf(&unicodepkg.Hanunoo)
+	env.Vars["Hatran"] = reflect.ValueOf(&unicodepkg.Hatran)
+	env.Vars["Hebrew"] = reflect.ValueOf(&unicodepkg.Hebrew)
+	env.Vars["Hex_Digit"] = reflect.ValueOf(&unicodepkg.Hex_Digit)
+	env.Vars["Hiragana"] = reflect.ValueOf(&unicodepkg.Hiragana)
+	env.Vars["Hyphen"] = reflect.ValueOf(&unicodepkg.Hyphen)
+	env.Vars["IDS_Binary_Operator"] = reflect.ValueOf(&unicodepkg.IDS_Binary_Operator)
+	env.Vars["IDS_Trinary_Operator"] = reflect.ValueOf(&unicodepkg.IDS_Trinary_Operator)
+	env.Vars["Ideographic"] = reflect.ValueOf(&unicodepkg.Ideographic)
+	env.Vars["Imperial_Aramaic"] = reflect.ValueOf(&unicodepkg.Imperial_Aramaic)
+	env.Funcs["In"] = reflect.ValueOf(unicodepkg.In)
+	env.Vars["Inherited"] = reflect.ValueOf(&unicodepkg.Inherited)
+	env.Vars["Inscriptional_Pahlavi"] = reflect.ValueOf(&unicodepkg.Inscriptional_Pahlavi)
+	env.Vars["Inscriptional_Parthian"] = reflect.ValueOf(&unicodepkg.Inscriptional_Parthian)
+	env.Funcs["Is"] = reflect.ValueOf(unicodepkg.Is)
+	env.Funcs["IsControl"] = reflect.ValueOf(unicodepkg.IsControl)
+	env.Funcs["IsDigit"] = reflect.ValueOf(unicodepkg.IsDigit)
+	env.Funcs["IsGraphic"] = reflect.ValueOf(unicodepkg.IsGraphic)
+	env.Funcs["IsLetter"] = reflect.ValueOf(unicodepkg.IsLetter)
+	env.Funcs["IsLower"] = reflect.ValueOf(unicodepkg.IsLower)
+	env.Funcs["IsMark"] = reflect.ValueOf(unicodepkg.IsMark)
+	env.Funcs["IsNumber"] = reflect.ValueOf(unicodepkg.IsNumber)
+	env.Funcs["IsOneOf"] = reflect.ValueOf(unicodepkg.IsOneOf)
+	env.Funcs["IsPrint"] = reflect.ValueOf(unicodepkg.IsPrint)
+	env.Funcs["IsPunct"] = reflect.ValueOf(unicodepkg.IsPunct)
+	env.Funcs["IsSpace"] = reflect.ValueOf(unicodepkg.IsSpace)
+	env.Funcs["IsSymbol"] = reflect.ValueOf(unicodepkg.IsSymbol)
+	env.Funcs["IsTitle"] = reflect.ValueOf(unicodepkg.IsTitle)
+	env.Funcs["IsUpper"] = reflect.ValueOf(unicodepkg.IsUpper)
+	env.Vars["Javanese"] = reflect.ValueOf(&unicodepkg.Javanese)
+	env.Vars["Join_Control"] = reflect.ValueOf(&unicodepkg.Join_Control)
+	env.Vars["Kaithi"] = reflect.ValueOf(&unicodepkg.Kaithi)
+	env.Vars["Kannada"] = reflect.ValueOf(&unicodepkg.Kannada)
+	env.Vars["Katakana"] = reflect.ValueOf(&unicodepkg.Katakana)
+	env.Vars["Kayah_Li"] = reflect.ValueOf(&unicodepkg.Kayah_Li)
+	env.Vars["Kharoshthi"] = reflect.ValueOf(&unicodepkg.Kharoshthi)
+	env.Vars["Khitan_Small_Script"] = reflect.ValueOf(&unicodepkg.Khitan_Small_Script)
+	env.Vars["Khmer"] = reflect.ValueOf(&unicodepkg.Khmer)
+	env.Vars["Khojki"] = reflect.ValueOf(&unicodepkg.Khojki)
+	env.Vars["Khudawadi"] = reflect.ValueOf(&unicodepkg.Khudawadi)
+	env.Vars["L"] = reflect.ValueOf(&unicodepkg.L)
+	env.Vars["Lao"] = reflect.ValueOf(&unicodepkg.Lao)
+	env.Vars["Latin"] = reflect.ValueOf(&unicodepkg.Latin)
+	env.Vars["Lepcha"] = reflect.ValueOf(&unicodepkg.Lepcha)
+	env.Vars["Letter"] = reflect.ValueOf(&unicodepkg.Letter)
+	env.Vars["Limbu"] = reflect.ValueOf(&unicodepkg.Limbu)
+	env.Vars["Linear_A"] = reflect.ValueOf(&unicodepkg.Linear_A)
+	env.Vars["Linear_B"] = reflect.ValueOf(&unicodepkg.Linear_B)
+	env.Vars["Lisu"] = reflect.ValueOf(&unicodepkg.Lisu)
+	env.Vars["Ll"] = reflect.ValueOf(&unicodepkg.Ll)
+	env.Vars["Lm"] = reflect.ValueOf(&unicodepkg.Lm)
+	env.Vars["Lo"] = reflect.ValueOf(&unicodepkg.Lo)
+	env.Vars["Logical_Order_Exception"] = reflect.ValueOf(&unicodepkg.Logical_Order_Exception)
+	env.Vars["Lower"] = reflect.ValueOf(&unicodepkg.Lower)
+	env.Consts["LowerCase"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "1", "0"))
+	env.Vars["Lt"] = reflect.ValueOf(&unicodepkg.Lt)
+	env.Vars["Lu"] = reflect.ValueOf(&unicodepkg.Lu)
+	env.Vars["Lycian"] = reflect.ValueOf(&unicodepkg.Lycian)
+	env.Vars["Lydian"] = reflect.ValueOf(&unicodepkg.Lydian)
+	env.Vars["M"] = reflect.ValueOf(&unicodepkg.M)
+	env.Vars["Mahajani"] = reflect.ValueOf(&unicodepkg.Mahajani)
+	env.Vars["Makasar"] = reflect.ValueOf(&unicodepkg.Makasar)
+	env.Vars["Malayalam"] = reflect.ValueOf(&unicodepkg.Malayalam)
+	env.Vars["Mandaic"] = reflect.ValueOf(&unicodepkg.Mandaic)
+	env.Vars["Manichaean"] = reflect.ValueOf(&unicodepkg.Manichaean)
+	env.Vars["Marchen"] = reflect.ValueOf(&unicodepkg.Marchen)
+	env.Vars["Mark"] = reflect.ValueOf(&unicodepkg.Mark)
+	env.Vars["Masaram_Gondi"] = reflect.ValueOf(&unicodepkg.Masaram_Gondi)
+	env.Consts["MaxASCII"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstRune, "127", "0"))
+	env.Consts["MaxCase"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "3", "0"))
+	env.Consts["MaxLatin1"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstRune, "255", "0"))
+	env.Consts["MaxRune"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstRune, "1114111", "0"))
+	env.Vars["Mc"] = reflect.ValueOf(&unicodepkg.Mc)
+	env.Vars["Me"] = reflect.ValueOf(&unicodepkg.Me)
+	env.Vars["Medefaidrin"] = reflect.ValueOf(&unicodepkg.Medefaidrin)
+	env.Vars["Meetei_Mayek"] = reflect.ValueOf(&unicodepkg.Meetei_Mayek)
+	env.Vars["Mende_Kikakui"] = reflect.ValueOf(&unicodepkg.Mende_Kikakui)
+	env.Vars["Meroitic_Cursive"] = reflect.ValueOf(&unicodepkg.Meroitic_Cursive)
+	env.Vars["Meroitic_Hieroglyphs"] = reflect.ValueOf(&unicodepkg.Meroitic_Hieroglyphs)
+	env.Vars["Miao"] = reflect.ValueOf(&unicodepkg.Miao)
+	env.Vars["Mn"] = reflect.ValueOf(&unicodepkg.Mn)
+	env.Vars["Modi"] = reflect.ValueOf(&unicodepkg.Modi)
+	env.Vars["Mongolian"] = reflect.ValueOf(&unicodepkg.Mongolian)
+	env.Vars["Mro"] = reflect.ValueOf(&unicodepkg.Mro)
+	env.Vars["Multani"] = reflect.ValueOf(&unicodepkg.Multani)
+	env.Vars["Myanmar"] = reflect.ValueOf(&unicodepkg.Myanmar)
+	env.Vars["N"] = reflect.ValueOf(&unicodepkg.N)
+	env.Vars["Nabataean"] = reflect.ValueOf(&unicodepkg.Nabataean)
+	env.Vars["Nandinagari"] = reflect.ValueOf(&unicodepkg.Nandinagari)
+	env.Vars["Nd"] = reflect.ValueOf(&unicodepkg.Nd)
+	env.Vars["New_Tai_Lue"] = reflect.ValueOf(&unicodepkg.New_Tai_Lue)
+	env.Vars["Newa"] = reflect.ValueOf(&unicodepkg.Newa)
+	env.Vars["Nko"] = reflect.ValueOf(&unicodepkg.Nko)
+	env.Vars["Nl"] = reflect.ValueOf(&unicodepkg.Nl)
+	env.Vars["No"] = reflect.ValueOf(&unicodepkg.No)
+	env.Vars["Noncharacter_Code_Point"] = reflect.ValueOf(&unicodepkg.Noncharacter_Code_Point)
+	env.Vars["Number"] = reflect.ValueOf(&unicodepkg.Number)
+	env.Vars["Nushu"] = reflect.ValueOf(&unicodepkg.Nushu)
+	env.Vars["Nyiakeng_Puachue_Hmong"] = reflect.ValueOf(&unicodepkg.Nyiakeng_Puachue_Hmong)
+	env.Vars["Ogham"] = reflect.ValueOf(&unicodepkg.Ogham)
+	env.Vars["Ol_Chiki"] = reflect.ValueOf(&unicodepkg.Ol_Chiki)
+	env.Vars["Old_Hungarian"] = reflect.ValueOf(&unicodepkg.Old_Hungarian)
+	env.Vars["Old_Italic"] = reflect.ValueOf(&unicodepkg.Old_Italic)
+	env.Vars["Old_North_Arabian"] = reflect.ValueOf(&unicodepkg.Old_North_Arabian)
+	env.Vars["Old_Permic"] = reflect.ValueOf(&unicodepkg.Old_Permic)
+	env.Vars["Old_Persian"] = reflect.ValueOf(&unicodepkg.Old_Persian)
+	env.Vars["Old_Sogdian"] = reflect.ValueOf(&unicodepkg.Old_Sogdian)
+	env.Vars["Old_South_Arabian"] = reflect.ValueOf(&unicodepkg.Old_South_Arabian)
+	env.Vars["Old_Turkic"] = reflect.ValueOf(&unicodepkg.Old_Turkic)
+	env.Vars["Oriya"] = reflect.ValueOf(&unicodepkg.Oriya)
+	env.Vars["Osage"] = reflect.ValueOf(&unicodepkg.Osage)
+	env.Vars["Osmanya"] = reflect.ValueOf(&unicodepkg.Osmanya)
+	env.Vars["Other"] = reflect.ValueOf(&unicodepkg.Other)
+	env.Vars["Other_Alphabetic"] = reflect.ValueOf(&unicodepkg.Other_Alphabetic)
+	env.Vars["Other_Default_Ignorable_Code_Point"] = reflect.ValueOf(&unicodepkg.Other_Default_Ignorable_Code_Point)
+	env.Vars["Other_Grapheme_Extend"] = reflect.ValueOf(&unicodepkg.Other_Grapheme_Extend)
+	env.Vars["Other_ID_Continue"] = reflect.ValueOf(&unicodepkg.Other_ID_Continue)
+	env.Vars["Other_ID_Start"] = reflect.ValueOf(&unicodepkg.Other_ID_Start)
+	env.Vars["Other_Lowercase"] = reflect.ValueOf(&unicodepkg.Other_Lowercase)
+	env.Vars["Other_Math"] = reflect.ValueOf(&unicodepkg.Other_Math)
+	env.Vars["Other_Uppercase"] = reflect.ValueOf(&unicodepkg.Other_Uppercase)
+	env.Vars["P"] = reflect.ValueOf(&unicodepkg.P)
+	env.Vars["Pahawh_Hmong"] = reflect.ValueOf(&unicodepkg.Pahawh_Hmong)
+	env.Vars["Palmyrene"] = reflect.ValueOf(&unicodepkg.Palmyrene)
+	env.Vars["Pattern_Syntax"] = reflect.ValueOf(&unicodepkg.Pattern_Syntax)
+	env.Vars["Pattern_White_Space"] = reflect.ValueOf(&unicodepkg.Pattern_White_Space)
+	env.Vars["Pau_Cin_Hau"] = reflect.ValueOf(&unicodepkg.Pau_Cin_Hau)
+	env.Vars["Pc"] = reflect.ValueOf(&unicodepkg.Pc)
+	env.Vars["Pd"] = reflect.ValueOf(&unicodepkg.Pd)
+	env.Vars["Pe"] = reflect.ValueOf(&unicodepkg.Pe)
+	env.Vars["Pf"] = reflect.ValueOf(&unicodepkg.Pf)
+	env.Vars["Phags_Pa"] = reflect.ValueOf(&unicodepkg.Phags_Pa)
+	env.Vars["Phoenician"] = reflect.ValueOf(&unicodepkg.Phoenician)
+	env.Vars["Pi"] = reflect.ValueOf(&unicodepkg.Pi)
+	env.Vars["Po"] = reflect.ValueOf(&unicodepkg.Po)
+	env.Vars["Prepended_Concatenation_Mark"] = reflect.ValueOf(&unicodepkg.Prepended_Concatenation_Mark)
+	env.Vars["PrintRanges"] = reflect.ValueOf(&unicodepkg.PrintRanges)
+	env.Vars["Properties"] = reflect.ValueOf(&unicodepkg.Properties)
+	env.Vars["Ps"] = reflect.ValueOf(&unicodepkg.Ps)
+	env.Vars["Psalter_Pahlavi"] = reflect.ValueOf(&unicodepkg.Psalter_Pahlavi)
+	env.Vars["Punct"] = reflect.ValueOf(&unicodepkg.Punct)
+	env.Vars["Quotation_Mark"] = reflect.ValueOf(&unicodepkg.Quotation_Mark)
+	env.Vars["Radical"] = reflect.ValueOf(&unicodepkg.Radical)
+	env.Types["Range16"] = reflect.TypeOf((*unicodepkg.Range16)(nil)).Elem()
+	env.Types["Range32"] = reflect.TypeOf((*unicodepkg.Range32)(nil)).Elem()
+	env.Types["RangeTable"] = reflect.TypeOf((*unicodepkg.RangeTable)(nil)).Elem()
+	env.Vars["Regional_Indicator"] = reflect.ValueOf(&unicodepkg.Regional_Indicator)
+	env.Vars["Rejang"] = reflect.ValueOf(&unicodepkg.Rejang)
+	env.Consts["ReplacementChar"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstRune, "65533", "0"))
+	env.Vars["Runic"] = reflect.ValueOf(&unicodepkg.Runic)
+	env.Vars["S"] = reflect.ValueOf(&unicodepkg.S)
+	env.Vars["STerm"] = reflect.ValueOf(&unicodepkg.STerm)
+	env.Vars["Samaritan"] = reflect.ValueOf(&unicodepkg.Samaritan)
+	env.Vars["Saurashtra"] = reflect.ValueOf(&unicodepkg.Saurashtra)
+	env.Vars["Sc"] = reflect.ValueOf(&unicodepkg.Sc)
+	env.Vars["Scripts"] = reflect.ValueOf(&unicodepkg.Scripts)
+	env.Vars["Sentence_Terminal"] = reflect.ValueOf(&unicodepkg.Sentence_Terminal)
+	env.Vars["Sharada"] = reflect.ValueOf(&unicodepkg.Sharada)
+	env.Vars["Shavian"] = reflect.ValueOf(&unicodepkg.Shavian)
+	env.Vars["Siddham"] = reflect.ValueOf(&unicodepkg.Siddham)
+	env.Vars["SignWriting"] = reflect.ValueOf(&unicodepkg.SignWriting)
+	env.Funcs["SimpleFold"] = reflect.ValueOf(unicodepkg.SimpleFold)
+	env.Vars["Sinhala"] = reflect.ValueOf(&unicodepkg.Sinhala)
+	env.Vars["Sk"] = reflect.ValueOf(&unicodepkg.Sk)
+	env.Vars["Sm"] = reflect.ValueOf(&unicodepkg.Sm)
+	env.Vars["So"] = reflect.ValueOf(&unicodepkg.So)
+	env.Vars["Soft_Dotted"] = reflect.ValueOf(&unicodepkg.Soft_Dotted)
+	env.Vars["Sogdian"] = reflect.ValueOf(&unicodepkg.Sogdian)
+	env.Vars["Sora_Sompeng"] = reflect.ValueOf(&unicodepkg.Sora_Sompeng)
+	env.Vars["Soyombo"] = reflect.ValueOf(&unicodepkg.Soyombo)
+	env.Vars["Space"] = reflect.ValueOf(&unicodepkg.Space)
+	env.Types["SpecialCase"] = reflect.TypeOf((*unicodepkg.SpecialCase)(nil)).Elem()
+	env.Vars["Sundanese"] = reflect.ValueOf(&unicodepkg.Sundanese)
+	env.Vars["Syloti_Nagri"] = reflect.ValueOf(&unicodepkg.Syloti_Nagri)
+	env.Vars["Symbol"] = reflect.ValueOf(&unicodepkg.Symbol)
+	env.Vars["Syriac"] = reflect.ValueOf(&unicodepkg.Syriac)
+	env.Vars["Tagalog"] = reflect.ValueOf(&unicodepkg.Tagalog)
+	env.Vars["Tagbanwa"] = reflect.ValueOf(&unicodepkg.Tagbanwa)
+	env.Vars["Tai_Le"] = reflect.ValueOf(&unicodepkg.Tai_Le)
+	env.Vars["Tai_Tham"] = reflect.ValueOf(&unicodepkg.Tai_Tham)
+	env.Vars["Tai_Viet"] = reflect.ValueOf(&unicodepkg.Tai_Viet)
+	env.Vars["Takri"] = reflect.ValueOf(&unicodepkg.Takri)
+	env.Vars["Tamil"] = reflect.ValueOf(&unicodepkg.Tamil)
+	env.Vars["Tangut"] = reflect.ValueOf(&unicodepkg.Tangut)
+	env.Vars["Telugu"] = reflect.ValueOf(&unicodepkg.Telugu)
+	env.Vars["Terminal_Punctuation"] = reflect.ValueOf(&unicodepkg.Terminal_Punctuation)
+	env.Vars["Thaana"] = reflect.ValueOf(&unicodepkg.Thaana)
+	env.Vars["Thai"] = reflect.ValueOf(&unicodepkg.Thai)
+	env.Vars["Tibetan"] = reflect.ValueOf(&unicodepkg.Tibetan)
+	env.Vars["Tifinagh"] = reflect.ValueOf(&unicodepkg.Tifinagh)
+	env.Vars["Tirhuta"] = reflect.ValueOf(&unicodepkg.Tirhuta)
+	env.Vars["Title"] = reflect.ValueOf(&unicodepkg.Title)
+	env.Consts["TitleCase"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "2", "0"))
+	env.Funcs["To"] = reflect.ValueOf(unicodepkg.To)
+	env.Funcs["ToLower"] = reflect.ValueOf(unicodepkg.ToLower)
+	env.Funcs["ToTitle"] = reflect.ValueOf(unicodepkg.ToTitle)
+	env.Funcs["ToUpper"] = reflect.ValueOf(unicodepkg.ToUpper)
+	env.Vars["TurkishCase"] = reflect.ValueOf(&unicodepkg.TurkishCase)
+	env.Vars["Ugaritic"] = reflect.ValueOf(&unicodepkg.Ugaritic)
+	env.Vars["Unified_Ideograph"] = reflect.ValueOf(&unicodepkg.Unified_Ideograph)
+	env.Vars["Upper"] = reflect.ValueOf(&unicodepkg.Upper)
+	env.Consts["UpperCase"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstInt, "0", "0"))
+	env.Consts["UpperLower"] = reflect.ValueOf(eval.NewConstNumber(eval.ConstRune, "1114112", "0"))
+	env.Vars["Vai"] = reflect.ValueOf(&unicodepkg.Vai)
+	env.Vars["Variation_Selector"] = reflect.ValueOf(&unicodepkg.Variation_Selector)
+	env.Consts["Version"] = reflect.ValueOf(unicodepkg.Version)
+	env.Vars["Wancho"] = reflect.ValueOf(&unicodepkg.Wancho)
+	env.Vars["Warang_Citi"] = reflect.ValueOf(&unicodepkg.Warang_Citi)
+	env.Vars["White_Space"] = reflect.ValueOf(&unicodepkg.White_Space)
+	env.Vars["Yezidi"] = reflect.ValueOf(&unicodepkg.Yezidi)
+	env.Vars["Yi"] = reflect.ValueOf(&unicodepkg.Yi)
+	env.Vars["Z"] = reflect.ValueOf(&unicodepkg.Z)
+	env.Vars["Zanabazar_Square"] = reflect.ValueOf(&unicodepkg.Zanabazar_Square)
+	env.Vars["Zl"] = reflect.ValueOf(&unicodepkg.Zl)
+	env.Vars["Zp"] = reflect.ValueOf(&unicodepkg.Zp)
+	env.Vars["Zs"] = reflect.ValueOf(&unicodepkg.Zs)
+	for _, add := range unicodeEnvVersions {
+		add(env)
+	}
+	return env
+}
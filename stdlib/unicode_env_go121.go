@@ -0,0 +1,26 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.21
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	unicodepkg "unicode"
+)
+
+// Declarations of package unicode added in go1.21.
+var _ = func() bool {
+	unicodeEnvVersions = append(unicodeEnvVersions, func(env *eval.SimpleEnv) {
+		env.Vars["Cypro_Minoan"] = reflect.ValueOf(&unicodepkg.Cypro_Minoan)
+		env.Vars["Kawi"] = reflect.ValueOf(&unicodepkg.Kawi)
+		env.Vars["Nag_Mundari"] = reflect.ValueOf(&unicodepkg.Nag_Mundari)
+		env.Vars["Old_Uyghur"] = reflect.ValueOf(&unicodepkg.Old_Uyghur)
+		env.Vars["Tangsa"] = reflect.ValueOf(&unicodepkg.Tangsa)
+		env.Vars["Toto"] = reflect.ValueOf(&unicodepkg.Toto)
+		env.Vars["Vithkuqi"] = reflect.ValueOf(&unicodepkg.Vithkuqi)
+	})
+	return true
+}()
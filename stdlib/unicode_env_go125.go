@@ -0,0 +1,22 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.25
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	unicodepkg "unicode"
+)
+
+// Declarations of package unicode added in go1.25.
+var _ = func() bool {
+	unicodeEnvVersions = append(unicodeEnvVersions, func(env *eval.SimpleEnv) {
+		env.Vars["CategoryAliases"] = reflect.ValueOf(&unicodepkg.CategoryAliases)
+		env.Vars["Cn"] = reflect.ValueOf(&unicodepkg.Cn)
+		env.Vars["LC"] = reflect.ValueOf(&unicodepkg.LC)
+	})
+	return true
+}()
@@ -0,0 +1,34 @@
+// Code generated by genenv; DO NOT EDIT.
+
+//go:build go1.27
+
+package stdlib
+
+import (
+	"reflect"
+
+	"github.com/0xfaded/eval"
+	unicodepkg "unicode"
+)
+
+// Declarations of package unicode added in go1.27.
+var _ = func() bool {
+	unicodeEnvVersions = append(unicodeEnvVersions, func(env *eval.SimpleEnv) {
+		env.Vars["Beria_Erfe"] = reflect.ValueOf(&unicodepkg.Beria_Erfe)
+		env.Vars["Garay"] = reflect.ValueOf(&unicodepkg.Garay)
+		env.Vars["Gurung_Khema"] = reflect.ValueOf(&unicodepkg.Gurung_Khema)
+		env.Vars["IDS_Unary_Operator"] = reflect.ValueOf(&unicodepkg.IDS_Unary_Operator)
+		env.Vars["ID_Compat_Math_Continue"] = reflect.ValueOf(&unicodepkg.ID_Compat_Math_Continue)
+		env.Vars["ID_Compat_Math_Start"] = reflect.ValueOf(&unicodepkg.ID_Compat_Math_Start)
+		env.Vars["Kirat_Rai"] = reflect.ValueOf(&unicodepkg.Kirat_Rai)
+		env.Vars["Modifier_Combining_Mark"] = reflect.ValueOf(&unicodepkg.Modifier_Combining_Mark)
+		env.Vars["Ol_Onal"] = reflect.ValueOf(&unicodepkg.Ol_Onal)
+		env.Vars["Sidetic"] = reflect.ValueOf(&unicodepkg.Sidetic)
+		env.Vars["Sunuwar"] = reflect.ValueOf(&unicodepkg.Sunuwar)
+		env.Vars["Tai_Yo"] = reflect.ValueOf(&unicodepkg.Tai_Yo)
+		env.Vars["Todhri"] = reflect.ValueOf(&unicodepkg.Todhri)
+		env.Vars["Tolong_Siki"] = reflect.ValueOf(&unicodepkg.Tolong_Siki)
+		env.Vars["Tulu_Tigalari"] = reflect.ValueOf(&unicodepkg.Tulu_Tigalari)
+	})
+	return true
+}()
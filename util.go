@@ -5,11 +5,20 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unsafe"
 
 	"go/ast"
 	"go/token"
 )
 
+// The PkgPath given to unexported fields of struct types created by the
+// interpreter. Fields with this PkgPath are accessible to interpreted code.
+const interpPkgPath = "github.com/0xfaded/eval/interp"
+
+// The largest size in bytes of an array type. reflect.ArrayOf panics for
+// arrays which would exceed the address space.
+const maxAlloc = 1 << 47
+
 // Equivalent of reflect.New, but unwraps internal Types into their original reflect.Type
 func hackedNew(t reflect.Type) reflect.Value {
 	return reflect.New(unhackType(t))
@@ -22,6 +31,10 @@ func unhackType(t reflect.Type) reflect.Type {
 		return tt.Type
 	case Byte:
 		return tt.Type
+	case *Interface:
+		return tt.Type
+	case *Named:
+		return tt.Type
 	default:
 		return t
 	}
@@ -29,7 +42,56 @@ func unhackType(t reflect.Type) reflect.Type {
 
 // Determine if type from is assignable to type to. From and To must not be ConstTypes
 func typeAssignableTo(from, to reflect.Type) bool {
-	return from.AssignableTo(unhackType(to))
+	// reflect treats an *Interface as interface{}, so the method set is checked here
+	_, virtualFrom := from.(*Interface)
+	if _, virtualTo := to.(*Interface); virtualFrom || virtualTo {
+		return to.Kind() == reflect.Interface && implements(from, to)
+	} else if _, named := from.(*Named); named && to.Kind() == reflect.Interface {
+		// Named values may be adapted to host interfaces
+		return implements(from, to)
+	}
+	fromNamed, _ := from.(*Named)
+	if toNamed, ok := to.(*Named); ok && fromNamed != nil {
+		return fromNamed == toNamed
+	}
+	return unhackType(from).AssignableTo(unhackType(to))
+}
+
+// Reports whether from, an expression assignable to to according to
+// typeAssignableTo, may also be assigned to to by the rules for Named types.
+// A Named is only assignable to itself, or to and from unnamed types with an
+// identical underlying type. Composite and func types hold the underlying
+// types of the Named types within them, so this is only checked where to is
+// exactly known, such as the type of a variable, and where from is a Named
+// or a variable.
+func namedExprAssignableTo(from Expr, to reflect.Type) bool {
+	fromType := from.KnownType()[0]
+	if named, ok := fromType.(*Named); ok && to.Kind() != reflect.Interface {
+		return named == to || namedIdentical(named, to)
+	} else if named, ok := to.(*Named); ok {
+		if ident, ok := skipSuperfluousParens(from).(*Ident); ok && ident.source == envVar {
+			return namedIdentical(named, fromType)
+		}
+	}
+	return true
+}
+
+// Reports whether the unnamed type t is identical to the underlying type of
+// named. The pointer type *T of a Named T is only identical to itself.
+func namedIdentical(named *Named, t reflect.Type) bool {
+	if _, ok := t.(*Named); ok {
+		return false
+	}
+	return named.elem == nil && t.Name() == "" && named.Type == unhackType(t)
+}
+
+// Determine if type from is convertible to type to. From and To must not be ConstTypes
+func typeConvertibleTo(from, to reflect.Type) bool {
+	_, virtualFrom := from.(*Interface)
+	if _, virtualTo := to.(*Interface); virtualFrom || virtualTo {
+		return typeAssignableTo(from, to)
+	}
+	return unhackType(from).ConvertibleTo(unhackType(to))
 }
 
 // exprAssignableTo(CheckExpr(expr), t), but errors are accumulated and a
@@ -37,21 +99,22 @@ func typeAssignableTo(from, to reflect.Type) bool {
 // The bool value will be false if and only if the conversion check
 // was reached and failed.
 func checkExprAssignableTo(expr ast.Expr, t reflect.Type, env Env) (Expr, bool, []error) {
-	var errs []error
-	aexpr, moreErrs := CheckExpr(expr, env)
-	if moreErrs != nil {
-		errs = append(errs, moreErrs...)
-	} else if _, err := expectSingleType(aexpr); err != nil {
-		errs = append(errs, err)
-	}
+	aexpr, errs := CheckExpr(expr, env)
+	ok, errs := checkedExprAssignableTo(aexpr, errs, t)
+	return aexpr, ok, errs
+}
+
+// As checkExprAssignableTo, for an expression which has already been checked
+// with errors errs. Expressions must not be checked twice, as checking may
+// rewrite the underlying ast.
+func checkedExprAssignableTo(aexpr Expr, errs []error, t reflect.Type) (bool, []error) {
 	if errs != nil {
-		return aexpr, true, errs
+		return true, errs
+	} else if _, err := expectSingleType(aexpr); err != nil {
+		return true, []error{err}
 	}
 	ok, convErrs := exprAssignableTo(aexpr, t)
-	if convErrs != nil {
-		errs = append(errs, convErrs...)
-	}
-	return aexpr, ok, errs
+	return ok, convErrs
 }
 
 // Determine if the result of from expr is assignable to type to. to must be a vanilla reflect.Type.
@@ -106,7 +169,7 @@ func isOpDefinedOn(op token.Token, t reflect.Type) bool {
 
 	switch t.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 		switch op {
 		case token.ADD, token.SUB, token.MUL, token.QUO,
 			token.REM, token.AND, token.OR, token.XOR, token.AND_NOT,
@@ -158,7 +221,7 @@ func isUnaryOpDefinedOn(op token.Token, t reflect.Type) bool {
 
 	switch t.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 		switch op {
 		case token.ADD, token.SUB, token.XOR:
 			return true
@@ -270,6 +333,24 @@ func evalTypedExpr(expr Expr, t knownType, env Env) (xs []reflect.Value, err err
         } else {
                 xs, err = EvalExpr(expr, env)
         }
+	// Values of virtual interfaces are stored as interface{}, and must be
+	// unwrapped before being assigned to a host interface type. Values of
+	// Named types are boxed or adapted before being assigned to any
+	// interface type.
+	for i, x := range xs {
+		if i >= len(t) {
+			break
+		} else if x.Kind() == reflect.Interface && !x.Type().AssignableTo(unhackType(t[i])) {
+			v := reflect.New(unhackType(t[i])).Elem()
+			if !x.IsNil() {
+				dynamic, dynamicT := dynamicValue(x)
+				v.Set(interfaceValue(dynamic, dynamicT, t[i]))
+			}
+			xs[i] = v
+		} else if t[i].Kind() == reflect.Interface && i < len(expr.KnownType()) {
+			xs[i] = interfaceValue(x, expr.KnownType()[i], t[i])
+		}
+	}
         return xs, err
 }
 
@@ -306,7 +387,7 @@ func checkInteger(expr ast.Expr, env Env) (aexpr Expr, i int, ok bool, checkErrs
 			if aexpr.IsConst() {
 				ii = aexpr.Const().Int()
 			}
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 			if aexpr.IsConst() {
 				ii = int64(aexpr.Const().Uint())
 			}
@@ -319,29 +400,28 @@ func checkInteger(expr ast.Expr, env Env) (aexpr Expr, i int, ok bool, checkErrs
 
 // Eval a node and cast it to an int. expr must be a *ConstNumber or integral type
 func evalInteger(expr Expr, env Env) (int, error) {
-        if expr.IsConst() {
-                x := expr.Const()
-                if ct, ok := expr.KnownType()[0].(ConstType); ok {
-                        cx, _ := promoteConstToTyped(ct, constValue(x), intType, expr)
+	var x reflect.Value
+	if expr.IsConst() {
+		x = expr.Const()
+		if ct, ok := expr.KnownType()[0].(ConstType); ok {
+			cx, _ := promoteConstToTyped(ct, constValue(x), intType, expr)
 			return int(reflect.Value(cx).Int()), nil
-                } else {
-			panic(dytc("const bool or string evaluated as int"))
-                }
-        } else {
-                xs, err := EvalExpr(expr, env);
+		}
+	} else {
+		xs, err := EvalExpr(expr, env);
 		if err != nil {
 			return 0, err
 		}
-		x := xs[0]
-		switch x.Type().Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			return int(x.Int()), nil
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			return int(x.Uint()), nil
-		default:
-			panic(dytc("non-integral type evaluated as int"))
-		}
-        }
+		x = xs[0]
+	}
+	switch x.Type().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(x.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int(x.Uint()), nil
+	default:
+		panic(dytc("non-integral type evaluated as int"))
+	}
 }
 
 func checkArrayIndex(expr ast.Expr, env Env) (aexpr Expr, i int, ok bool, checkErrs []error) {
@@ -366,7 +446,7 @@ func checkArrayIndex(expr ast.Expr, env Env) (aexpr Expr, i int, ok bool, checkE
 		switch t.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 			ii = aexpr.Const().Int()
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 			ii = int64(aexpr.Const().Uint())
 		default:
 			return aexpr, 0, false, checkErrs
@@ -434,6 +514,8 @@ func isStaticTypeComparable(t reflect.Type) bool {
 		return false
 	case reflect.Struct:
 		return isStructComparable(t)
+	case reflect.Array:
+		return isStaticTypeComparable(t.Elem())
 	default:
 		return true
 	}
@@ -521,6 +603,11 @@ func isBlankIdentifier(blank ast.Expr) bool {
 	return false
 }
 
+func isEllipsis(expr ast.Expr) bool {
+	_, ok := expr.(*ast.Ellipsis)
+	return ok
+}
+
 func multivalueOk(expr Expr) bool {
 	switch e := skipSuperfluousParens(expr).(type) {
 	case *TypeAssertExpr:
@@ -554,3 +641,36 @@ func equal(x, y reflect.Value) (bool, error) {
 	}
 }
 
+
+// Equivalent of v.FieldByIndex(index), except that unexported fields of
+// struct types created by the interpreter remain settable and interfaceable.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		if v.Type().Field(i).PkgPath == interpPkgPath && v.CanInterface() {
+			if !v.CanAddr() {
+				addr := reflect.New(v.Type()).Elem()
+				addr.Set(v)
+				v = addr
+			}
+			f := v.Field(i)
+			v = reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+		} else {
+			v = v.Field(i)
+		}
+	}
+	return v
+}
+
+// Copy values, which may refer to variables, so that later assignments to
+// those variables are not observed.
+func copyValues(vs []reflect.Value) []reflect.Value {
+	copies := make([]reflect.Value, len(vs))
+	for i, v := range vs {
+		copies[i] = reflect.New(v.Type()).Elem()
+		copies[i].Set(v)
+	}
+	return copies
+}
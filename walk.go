@@ -42,6 +42,10 @@ func walk(expr ast.Expr, visitor exprVisitor) {
 			walk(expr.Index, visitor)
 			walk(expr.X, visitor)
 		}
+	case *IndexListExpr:
+		if visitor.visit(expr) {
+			walk(expr.X, visitor)
+		}
 	case *SliceExpr:
 		if visitor.visit(expr) {
 			walk(expr.Low, visitor)